@@ -0,0 +1,271 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+// Factory defines an object factory used by a builder to create objects.
+// It is implemented by factory.Factory and factory.Global.
+type Factory interface {
+	Create(name string, arguments ...interface{}) (interface{}, error)
+	CreateNamed(name string, options interface{}) (interface{}, error)
+	SchemaOf(name string) (factory.Schema, error)
+}
+
+// Builder defines a builder of object graphs described by JSON documents.
+//
+// Every JSON object with the discriminator field is replaced with an object
+// created by a factory with an object constructor named by the discriminator
+// field value. Remaining fields of the JSON object and fields of its options
+// field are passed to the object constructor as named options. Nested JSON
+// objects and arrays are built first, so objects can be passed as options of
+// other objects.
+type Builder struct {
+	factory       Factory
+	discriminator string
+	optionsField  string
+}
+
+// Error defines an error of building an object located in a JSON document
+// by a JSON pointer.
+type Error struct {
+	Pointer string
+	Err     error
+}
+
+// New creates a new builder creating objects with a given factory.
+func New(f Factory, options ...Option) *Builder {
+	b := &Builder{
+		factory:       f,
+		discriminator: DefaultDiscriminator,
+		optionsField:  DefaultOptionsField,
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	return b
+}
+
+// Error returns error message with JSON pointer.
+func (e *Error) Error() string {
+	return "cannot build object at \"" + e.Pointer + "\": " + e.Err.Error()
+}
+
+// Unwrap returns the error of building an object.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Build builds an object graph described by a given JSON document.
+func (b *Builder) Build(data []byte) (interface{}, error) {
+	return b.Decode(bytes.NewReader(data))
+}
+
+// Decode reads a JSON document and builds an object graph described by it.
+func (b *Builder) Decode(reader io.Reader) (interface{}, error) {
+	var document interface{}
+
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		return nil, rterror.New("cannot decode JSON document", err)
+	}
+
+	return b.BuildValue(document)
+}
+
+// BuildValue builds an object graph described by a given decoded JSON document.
+func (b *Builder) BuildValue(document interface{}) (interface{}, error) {
+	return b.build("", document)
+}
+
+// build builds a given JSON value located by a given JSON pointer.
+func (b *Builder) build(pointer string, value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if _, ok := value[b.discriminator]; ok {
+			return b.create(pointer, value)
+		}
+
+		return b.buildObject(pointer, value)
+	case []interface{}:
+		values := make([]interface{}, len(value))
+
+		for index, item := range value {
+			built, err := b.build(pointer+"/"+strconv.Itoa(index), item)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[index] = built
+		}
+
+		return values, nil
+	default:
+		return value, nil
+	}
+}
+
+// buildObject builds all fields of a given JSON object.
+func (b *Builder) buildObject(pointer string, object map[string]interface{}) (map[string]interface{}, error) {
+	built := make(map[string]interface{}, len(object))
+
+	for _, key := range sortedKeys(object) {
+		value, err := b.build(pointer+"/"+escape(key), object[key])
+
+		if err != nil {
+			return nil, err
+		}
+
+		built[key] = value
+	}
+
+	return built, nil
+}
+
+// create creates an object described by a given JSON object with the
+// discriminator field.
+func (b *Builder) create(pointer string, object map[string]interface{}) (interface{}, error) {
+	name, ok := object[b.discriminator].(string)
+
+	if !ok {
+		return nil, &Error{
+			Pointer: pointer + "/" + escape(b.discriminator),
+			Err:     rterror.New("discriminator must be a string", b.discriminator),
+		}
+	}
+
+	options, pointers, err := b.options(pointer, object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := b.factory.SchemaOf(name)
+
+	if err != nil {
+		return nil, &Error{Pointer: pointer + "/" + escape(b.discriminator), Err: err}
+	}
+
+	var created interface{}
+
+	if (schema == nil) && (len(options) == 0) {
+		created, err = b.factory.Create(name)
+	} else {
+		created, err = b.factory.CreateNamed(name, options)
+	}
+
+	if err != nil {
+		var invalid *factory.InvalidArgumentsError
+
+		if errors.As(err, &invalid) && (pointers[invalid.Argument] != "") {
+			pointer = pointers[invalid.Argument]
+		}
+
+		return nil, &Error{Pointer: pointer, Err: err}
+	}
+
+	return created, nil
+}
+
+// options returns built named options of a given JSON object with the
+// discriminator field and JSON pointers of them.
+func (b *Builder) options(pointer string, object map[string]interface{}) (factory.Options, map[string]string, error) {
+	options := factory.Options{}
+	pointers := map[string]string{}
+
+	add := func(prefix string, fields map[string]interface{}) error {
+		for _, key := range sortedKeys(fields) {
+			location := prefix + "/" + escape(key)
+
+			if _, ok := options[key]; ok {
+				return &Error{
+					Pointer: location,
+					Err:     rterror.New("option is defined more than once", key),
+				}
+			}
+
+			value, err := b.build(location, fields[key])
+
+			if err != nil {
+				return err
+			}
+
+			options[key] = value
+			pointers[key] = location
+		}
+
+		return nil
+	}
+
+	fields := make(map[string]interface{}, len(object))
+
+	for key, value := range object {
+		if (key != b.discriminator) && (key != b.optionsField) {
+			fields[key] = value
+		}
+	}
+
+	if err := add(pointer, fields); err != nil {
+		return nil, nil, err
+	}
+
+	if value, ok := object[b.optionsField]; ok {
+		nested, isObject := value.(map[string]interface{})
+
+		if !isObject {
+			return nil, nil, &Error{
+				Pointer: pointer + "/" + escape(b.optionsField),
+				Err:     rterror.New("options must be an object", b.optionsField),
+			}
+		}
+
+		if err := add(pointer+"/"+escape(b.optionsField), nested); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return options, pointers, nil
+}
+
+// sortedKeys returns sorted keys of a given JSON object.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// escape escapes a given JSON object key to be used in a JSON pointer.
+func escape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/builder"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type Stage struct {
+	Kind  string
+	Level int
+	Next  interface{}
+}
+
+// nolint: gochecknoglobals
+var StageSchema = factory.Schema{
+	{Name: "level", Type: reflect.TypeOf(0), Optional: true, Default: 1},
+	{Name: "next", Optional: true},
+}
+
+func NewFactory() *factory.Factory {
+	f := factory.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance))
+
+	for _, kind := range []string{"gzip", "s3"} {
+		kind := kind
+
+		f.SetWithSchema(kind, func(arguments ...interface{}) (interface{}, error) {
+			return &Stage{Kind: kind, Level: arguments[0].(int), Next: arguments[1]}, nil
+		}, StageSchema)
+	}
+
+	f.Set("stdout", func(...interface{}) (interface{}, error) {
+		return &Stage{Kind: "stdout"}, nil
+	})
+
+	return f
+}
+
+func TestBuilderBuild(test *testing.T) {
+	object, err := builder.New(NewFactory()).Build([]byte(`{
+		"type": "gzip",
+		"options": {"level": "9"},
+		"next": {
+			"type": "s3",
+			"next": {"type": "stdout"}
+		}
+	}`))
+
+	assert.NoError(test, err)
+	assert.Equal(test, &Stage{
+		Kind:  "gzip",
+		Level: 9,
+		Next: &Stage{
+			Kind:  "s3",
+			Level: 1,
+			Next:  &Stage{Kind: "stdout"},
+		},
+	}, object)
+}
+
+func TestBuilderBuildContainers(test *testing.T) {
+	object, err := builder.New(NewFactory()).Decode(strings.NewReader(`{
+		"pipelines": [{"type": "stdout"}, {"type": "gzip", "level": 3}],
+		"name": "main"
+	}`))
+
+	assert.NoError(test, err)
+	assert.Equal(test, map[string]interface{}{
+		"pipelines": []interface{}{
+			&Stage{Kind: "stdout"},
+			&Stage{Kind: "gzip", Level: 3},
+		},
+		"name": "main",
+	}, object)
+}
+
+func TestBuilderOptions(test *testing.T) {
+	b := builder.New(NewFactory(), builder.WithDiscriminator("kind"), builder.WithOptionsField("with"))
+
+	object, err := b.Build([]byte(`{"kind": "gzip", "with": {"level": 5}}`))
+
+	assert.NoError(test, err)
+	assert.Equal(test, &Stage{Kind: "gzip", Level: 5}, object)
+}
+
+func TestBuilderGlobal(test *testing.T) {
+	global := factory.Named("builder")
+	defer global.RemoveAll()
+
+	global.SetWithSchema("gzip", func(arguments ...interface{}) (interface{}, error) {
+		return &Stage{Kind: "gzip", Level: arguments[0].(int)}, nil
+	}, StageSchema)
+
+	object, err := builder.New(global).Build([]byte(`{"type": "gzip"}`))
+
+	assert.NoError(test, err)
+	assert.Equal(test, &Stage{Kind: "gzip", Level: 1}, object)
+}
+
+func TestBuilderErrors(test *testing.T) {
+	b := builder.New(NewFactory())
+
+	for _, testCase := range []struct {
+		document string
+		pointer  string
+	}{
+		{document: `{"type": 1}`, pointer: "/type"},
+		{document: `{"type": "gzpi"}`, pointer: "/type"},
+		{document: `{"type": "gzip", "options": []}`, pointer: "/options"},
+		{document: `{"type": "gzip", "level": 1, "options": {"level": 2}}`, pointer: "/options/level"},
+		{document: `{"type": "gzip", "options": {"level": "high"}}`, pointer: "/options/level"},
+		{document: `{"type": "gzip", "levle": 1}`, pointer: "/levle"},
+		{document: `{"type": "stdout", "level": 1}`, pointer: ""},
+		{document: `{"type": "gzip", "next": {"type": "s3", "next": {"type": "nope"}}}`, pointer: "/next/next/type"},
+		{document: `{"a/b": [{"c~d": {"type": "nope"}}]}`, pointer: "/a~1b/0/c~0d/type"},
+	} {
+		_, err := b.Build([]byte(testCase.document))
+
+		var buildErr *builder.Error
+
+		if assert.True(test, errors.As(err, &buildErr), testCase.document) {
+			assert.Equal(test, testCase.pointer, buildErr.Pointer, testCase.document)
+			assert.Contains(test, err.Error(), `"`+testCase.pointer+`"`)
+		}
+	}
+}
+
+func TestBuilderErrorCause(test *testing.T) {
+	_, err := builder.New(NewFactory()).Build([]byte(`{"type": "gzpi"}`))
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, registry.Names{"gzip"}, notRegistered.Suggestions)
+}
+
+func TestBuilderInvalidJSON(test *testing.T) {
+	_, err := builder.New(NewFactory()).Build([]byte(`{`))
+	assert.Error(test, err)
+}
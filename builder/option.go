@@ -0,0 +1,40 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+// DefaultDiscriminator defines the default name of the field holding
+// a name of object constructor.
+const DefaultDiscriminator = "type"
+
+// DefaultOptionsField defines the default name of the field holding named
+// options of object constructor.
+const DefaultOptionsField = "options"
+
+// Option defines a builder option used to configure a builder object.
+type Option func(b *Builder)
+
+// WithDiscriminator sets a name of the field holding a name of object constructor.
+func WithDiscriminator(field string) Option {
+	return func(b *Builder) {
+		b.discriminator = field
+	}
+}
+
+// WithOptionsField sets a name of the field holding named options of object constructor.
+func WithOptionsField(field string) Option {
+	return func(b *Builder) {
+		b.optionsField = field
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// PrefixParameter defines a query parameter used to filter entries by name prefix.
+const PrefixParameter = "prefix"
+
+// FormatParameter defines a query parameter used to select output format.
+const FormatParameter = "format"
+
+// FormatJSON defines a value of the format query parameter that selects JSON output.
+const FormatJSON = "json"
+
+// MIMEJSON defines a MIME type of JSON output.
+const MIMEJSON = "application/json"
+
+var gTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Registry</title></head>
+<body>
+<h1>Registry</h1>
+<table>
+<tr><th>Name</th><th>Type</th><th>Source</th><th>Value</th></tr>
+{{range .Registry}}<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Source}}</td><td>{{if .Value}}{{.Value}}{{else}}&lt;redacted&gt;{{end}}</td></tr>
+{{end}}</table>
+<h1>Factory</h1>
+<table>
+<tr><th>Name</th><th>Constructor</th><th>Source</th></tr>
+{{range .Factory}}<tr><td>{{.Name}}</td><td>{{.Constructor}}</td><td>{{.Source}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`)) // nolint: gochecknoglobals
+
+// Object defines an exposed registry entry.
+type Object struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Source string  `json:"source,omitempty"`
+	Value  *string `json:"value,omitempty"`
+}
+
+// Constructor defines an exposed factory entry.
+type Constructor struct {
+	Name        string `json:"name"`
+	Constructor string `json:"constructor"`
+	Source      string `json:"source,omitempty"`
+}
+
+// Contents defines exposed registry and factory entries.
+type Contents struct {
+	Registry []Object      `json:"registry"`
+	Factory  []Constructor `json:"factory"`
+}
+
+// Handler defines an HTTP handler that exposes contents of the global registry
+// and the global factory. It only reads from them.
+type Handler struct {
+	prefix   string
+	redactor Redactor
+}
+
+// New creates a new handler object.
+func New(options ...Option) *Handler {
+	h := &Handler{}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	return h
+}
+
+// Contents returns exposed entries with names starting with a given prefix
+// and a prefix configured for handler.
+func (h *Handler) Contents(prefix string) *Contents {
+	contents := &Contents{
+		Registry: []Object{},
+		Factory:  []Constructor{},
+	}
+
+	for _, entry := range registry.Entries() {
+		if h.isExposed(entry.Name, prefix) {
+			contents.Registry = append(contents.Registry, Object{
+				Name:   entry.Name,
+				Type:   typeName(entry.Object),
+				Source: entry.Source,
+				Value:  h.redact(entry.Name, entry.Object),
+			})
+		}
+	}
+
+	for _, entry := range factory.Entries() {
+		if h.isExposed(entry.Name, prefix) {
+			contents.Factory = append(contents.Factory, Constructor{
+				Name:        entry.Name,
+				Constructor: functionName(entry.Object),
+				Source:      entry.Source,
+			})
+		}
+	}
+
+	return contents
+}
+
+// ServeHTTP writes exposed entries as HTML or as JSON if requested with the
+// format query parameter or the Accept header.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", "GET, HEAD")
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	query := request.URL.Query()
+	contents := h.Contents(query.Get(PrefixParameter))
+
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if query.Get(FormatParameter) == FormatJSON || strings.Contains(request.Header.Get("Accept"), MIMEJSON) {
+		writer.Header().Set("Content-Type", MIMEJSON)
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "    ")
+
+		if err := encoder.Encode(contents); err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := gTemplate.Execute(writer, contents); err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// isExposed returns true if entry with a given name can be exposed.
+func (h *Handler) isExposed(name, prefix string) bool {
+	return strings.HasPrefix(name, h.prefix) && strings.HasPrefix(name, prefix)
+}
+
+// redact returns exposed value or nil if value cannot be exposed.
+func (h *Handler) redact(name string, object interface{}) *string {
+	if h.redactor == nil {
+		return nil
+	}
+
+	value, ok := h.redactor(name, object)
+
+	if !ok {
+		return nil
+	}
+
+	return &value
+}
+
+// typeName returns type name of a given object.
+func typeName(object interface{}) string {
+	if object == nil {
+		return "<nil>"
+	}
+
+	return reflect.TypeOf(object).String()
+}
+
+// functionName returns full function name of a given function object.
+func functionName(object interface{}) string {
+	value := reflect.ValueOf(object)
+
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return "<nil>"
+	}
+
+	if function := runtime.FuncForPC(value.Pointer()); function != nil {
+		return function.Name()
+	}
+
+	return value.Type().String()
+}
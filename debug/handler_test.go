@@ -0,0 +1,159 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/debug"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func Constructor(...interface{}) (interface{}, error) {
+	return new(struct{}), nil
+}
+
+func setup(test *testing.T) {
+	registry.Sets(registry.Objects{
+		"codec.json": "secret",
+		"codec.gob":  5,
+		"driver":     "secret",
+	})
+
+	factory.Sets(factory.Constructors{
+		"codec.json": Constructor,
+		"driver":     Constructor,
+	})
+
+	test.Cleanup(func() {
+		registry.RemoveAll()
+		factory.RemoveAll()
+	})
+}
+
+func get(test *testing.T, handler http.Handler, target string) *debug.Contents {
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	assert.Equal(test, http.StatusOK, recorder.Code)
+	assert.Equal(test, debug.MIMEJSON, recorder.Header().Get("Content-Type"))
+
+	contents := new(debug.Contents)
+
+	assert.NoError(test, json.Unmarshal(recorder.Body.Bytes(), contents))
+
+	return contents
+}
+
+func TestHandlerJSON(test *testing.T) {
+	setup(test)
+
+	contents := get(test, debug.New(), "/debug/registry?format=json")
+
+	assert.Len(test, contents.Registry, 3)
+	assert.Equal(test, "codec.gob", contents.Registry[0].Name)
+	assert.Equal(test, "int", contents.Registry[0].Type)
+	assert.Contains(test, contents.Registry[0].Source, "handler_test.go:")
+	assert.Nil(test, contents.Registry[0].Value)
+
+	assert.Len(test, contents.Factory, 2)
+	assert.Equal(test, "codec.json", contents.Factory[0].Name)
+	assert.Equal(test, "gitlab.com/tymonx/go-patterns/debug_test.Constructor", contents.Factory[0].Constructor)
+}
+
+func TestHandlerAcceptJSON(test *testing.T) {
+	setup(test)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept", debug.MIMEJSON)
+
+	debug.New().ServeHTTP(recorder, request)
+
+	assert.Equal(test, debug.MIMEJSON, recorder.Header().Get("Content-Type"))
+}
+
+func TestHandlerPrefix(test *testing.T) {
+	setup(test)
+
+	contents := get(test, debug.New(debug.WithPrefix("codec.")), "/?format=json")
+
+	assert.Len(test, contents.Registry, 2)
+	assert.Len(test, contents.Factory, 1)
+
+	contents = get(test, debug.New(debug.WithPrefix("codec.")), "/?format=json&prefix=codec.j")
+
+	assert.Len(test, contents.Registry, 1)
+	assert.Len(test, contents.Factory, 1)
+
+	contents = get(test, debug.New(debug.WithPrefix("codec.")), "/?format=json&prefix=driver")
+
+	assert.Empty(test, contents.Registry)
+	assert.Empty(test, contents.Factory)
+}
+
+func TestHandlerRedactor(test *testing.T) {
+	setup(test)
+
+	handler := debug.New(debug.WithRedactor(func(name string, object interface{}) (string, bool) {
+		if name == "codec.gob" {
+			return fmt.Sprint(object), true
+		}
+
+		return "", false
+	}))
+
+	contents := get(test, handler, "/?format=json")
+
+	assert.Len(test, contents.Registry, 3)
+	assert.Equal(test, "5", *contents.Registry[0].Value)
+	assert.Nil(test, contents.Registry[1].Value)
+	assert.Nil(test, contents.Registry[2].Value)
+}
+
+func TestHandlerHTML(test *testing.T) {
+	setup(test)
+
+	handler := debug.New(debug.WithRedactor(func(name string, object interface{}) (string, bool) {
+		return "<visible>", name == "codec.gob"
+	}))
+
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(test, http.StatusOK, recorder.Code)
+	assert.Contains(test, recorder.Header().Get("Content-Type"), "text/html")
+	assert.Contains(test, recorder.Body.String(), "codec.json")
+	assert.Contains(test, recorder.Body.String(), "&lt;visible&gt;")
+	assert.Contains(test, recorder.Body.String(), "&lt;redacted&gt;")
+	assert.NotContains(test, recorder.Body.String(), "secret")
+	assert.Contains(test, recorder.Body.String(), "debug_test.Constructor")
+}
+
+func TestHandlerMethodNotAllowed(test *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	debug.New().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(test, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+// Redactor defines a function that decides if and how a registered value is
+// exposed. It returns a value representation and true if a value can be
+// exposed, otherwise it returns false.
+type Redactor func(name string, object interface{}) (value string, ok bool)
+
+// Option defines a handler option used to configure a handler object.
+type Option func(h *Handler)
+
+// WithPrefix sets a name prefix used to filter exposed registry and factory
+// entries. Only entries with names starting with a given prefix are exposed.
+func WithPrefix(prefix string) Option {
+	return func(h *Handler) {
+		h.prefix = prefix
+	}
+}
+
+// WithRedactor sets a redactor used to expose registered values.
+func WithRedactor(redactor Redactor) Option {
+	return func(h *Handler) {
+		h.redactor = redactor
+	}
+}
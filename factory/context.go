@@ -0,0 +1,195 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// overlayKey defines a context key used to store factory overlays.
+type overlayKey struct{}
+
+// overlay defines object constructors overriding global factory object
+// constructors in a context.
+type overlay struct {
+	parent       *overlay
+	constructors Constructors
+}
+
+// Lookup defines a read-only view of the global factory with object
+// constructors overridden by context overlays. Overlays are consulted first,
+// the most recently added one first, and then the global factory. Objects are
+// created with the context the lookup was returned for.
+type Lookup struct {
+	ctx     context.Context
+	overlay *overlay
+}
+
+// WithOverlay returns a copy of parent context with given object constructors
+// overriding global factory object constructors for objects created through
+// that context. Global factory is not modified. It panics if any name does not
+// pass global factory constraints.
+func WithOverlay(ctx context.Context, constructors Constructors) context.Context {
+	o := &overlay{
+		constructors: make(Constructors, len(constructors)),
+	}
+
+	o.parent, _ = ctx.Value(overlayKey{}).(*overlay)
+
+	getDefault().guard.Read(func() {
+		for name, constructor := range constructors {
+			key, err := getInstance().registry.Key(name)
+
+			if err != nil {
+				panic(err)
+			}
+
+			o.constructors[key] = constructor
+		}
+	})
+
+	return context.WithValue(ctx, overlayKey{}, o)
+}
+
+// FromContext returns a read-only view of the global factory with object
+// constructors overridden by overlays stored in a given context.
+func FromContext(ctx context.Context) Lookup {
+	o, _ := ctx.Value(overlayKey{}).(*overlay)
+
+	return Lookup{
+		ctx:     ctx,
+		overlay: o,
+	}
+}
+
+// Create creates a new object based on given name.
+func (l Lookup) Create(name string, arguments ...interface{}) (object interface{}, err error) {
+	getDefault().guard.Read(func() {
+		object, err = l.create(name, arguments...)
+	})
+
+	return object, err
+}
+
+// Creates creates a list of new objects based on given names. When the
+// context is done, remaining objects are not created.
+func (l Lookup) Creates(names []string, arguments ...interface{}) ([]interface{}, error) {
+	objects := make([]interface{}, 0, len(names))
+	errs := make([]interface{}, 0, len(names))
+	ctx := l.context()
+
+	getDefault().guard.Read(func() {
+		for _, name := range names {
+			if err := ctx.Err(); err != nil {
+				errs = append(errs, rterror.New("object was not created", name, err))
+				break
+			}
+
+			object, err := l.create(name, arguments...)
+
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			objects = append(objects, object)
+		}
+	})
+
+	if len(errs) != 0 {
+		return objects, rterror.New("cannot create objects", errs...)
+	}
+
+	return objects, nil
+}
+
+// Get returns registered object constructor by given name.
+func (l Lookup) Get(name string) (constructor Constructor, err error) {
+	getDefault().guard.Read(func() {
+		var ok bool
+
+		if constructor, ok = l.find(name); !ok {
+			constructor, err = getInstance().Get(name)
+		}
+	})
+
+	return constructor, err
+}
+
+// IsExist returns true if object constructor with given name was registered, otherwise it returns false.
+func (l Lookup) IsExist(name string) (value bool) {
+	getDefault().guard.Read(func() {
+		_, value = l.find(name)
+
+		if !value {
+			value = getInstance().IsExist(name)
+		}
+	})
+
+	return value
+}
+
+// create creates a new object based on given name. Global factory must be locked.
+func (l Lookup) create(name string, arguments ...interface{}) (interface{}, error) {
+	constructor, ok := l.find(name)
+
+	if !ok {
+		return getInstance().CreateContext(l.context(), name, arguments...)
+	}
+
+	// Overlay names were validated by WithOverlay
+	key, _ := getInstance().registry.Key(name)
+
+	start := time.Now()
+	object, err := getInstance().construct(l.context(), name, withContext(constructor), arguments...)
+	getInstance().registry.Recorder().Create(key, time.Since(start), err)
+
+	return object, err
+}
+
+// context returns a context used to create objects. A zero lookup uses
+// a background context.
+func (l Lookup) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+
+	return l.ctx
+}
+
+// find returns object constructor with given name found in overlays.
+// Global factory must be locked.
+func (l Lookup) find(name string) (Constructor, bool) {
+	if l.overlay == nil {
+		return nil, false
+	}
+
+	key, err := getInstance().registry.Key(name)
+
+	if err != nil {
+		return nil, false
+	}
+
+	for o := l.overlay; o != nil; o = o.parent {
+		if constructor, ok := o.constructors[key]; ok {
+			return constructor, true
+		}
+	}
+
+	return nil, false
+}
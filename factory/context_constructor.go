@@ -0,0 +1,192 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// ContextConstructor defines an object constructor function for creating
+// objects that receives a context. It should stop creating an object and
+// return an error when the context is done.
+type ContextConstructor func(ctx context.Context, arguments ...interface{}) (object interface{}, err error)
+
+// result defines a result of an object constructor call. It holds a value
+// passed to panic if an object constructor panicked.
+type result struct {
+	object   interface{}
+	err      error
+	panicked bool
+	panic    interface{}
+}
+
+// AddContext adds a context-aware object constructor with a given unique id
+// to registry. It is called with a background context by Create.
+func (f *Factory) AddContext(name string, constructor ContextConstructor) error {
+	return f.registry.Add(name, newContextRegistration(constructor))
+}
+
+// SetContext sets a context-aware object constructor with a given unique id
+// to registry. It is called with a background context by Create.
+func (f *Factory) SetContext(name string, constructor ContextConstructor) *Factory {
+	f.registry.Set(name, newContextRegistration(constructor))
+	return f
+}
+
+// CreateContext creates a new object based on given name. The context is
+// passed to context-aware object constructors. If the context is done before
+// an object constructor returns, CreateContext returns immediately with the
+// context error and an object created later is closed if it implements the
+// io.Closer interface.
+func (f *Factory) CreateContext(ctx context.Context, name string, arguments ...interface{}) (object interface{}, err error) {
+	start := time.Now()
+	object, err = f.create(ctx, nil, name, arguments...)
+	f.record(name, start, err)
+
+	return object, err
+}
+
+// CreatesContext creates a list of new objects based on given names. When the
+// context is done, remaining objects are not created.
+func (f *Factory) CreatesContext(ctx context.Context, names []string, arguments ...interface{}) ([]interface{}, error) {
+	objects := make([]interface{}, 0, len(names))
+	errs := make([]interface{}, 0, len(names))
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, rterror.New("object was not created", name, err))
+			break
+		}
+
+		object, err := f.CreateContext(ctx, name, arguments...)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	if len(errs) != 0 {
+		return objects, rterror.New("cannot create objects", errs...)
+	}
+
+	return objects, nil
+}
+
+// invoke calls a given object constructor with a given context. It returns
+// the context error if the context is done before the object constructor
+// returns. If the object constructor panics, invoke panics with the same value
+// in the calling goroutine.
+func invoke(ctx context.Context, constructor ContextConstructor, arguments []interface{}) (interface{}, error) {
+	if ctx.Done() == nil {
+		return constructor(ctx, arguments...)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		r := result{panicked: true}
+
+		defer func() {
+			if r.panicked {
+				r.panic = recover()
+			}
+
+			done <- r
+		}()
+
+		r.object, r.err = constructor(ctx, arguments...)
+		r.panicked = false
+	}()
+
+	select {
+	case r := <-done:
+		if r.panicked {
+			panic(r.panic)
+		}
+
+		return r.object, r.err
+	case <-ctx.Done():
+		go discard(done)
+		return nil, ctx.Err()
+	}
+}
+
+// discard closes an abandoned object if it implements the io.Closer interface.
+func discard(done <-chan result) {
+	r := <-done
+
+	if closer, ok := r.object.(io.Closer); ok && (r.err == nil) && !r.panicked {
+		closer.Close()
+	}
+}
+
+// withContext returns a context-aware object constructor calling a given
+// object constructor.
+func withContext(constructor Constructor) ContextConstructor {
+	if constructor == nil {
+		return nil
+	}
+
+	return func(_ context.Context, arguments ...interface{}) (interface{}, error) {
+		return constructor(arguments...)
+	}
+}
+
+// withoutContext returns an object constructor calling a given context-aware
+// object constructor with a background context.
+func withoutContext(constructor ContextConstructor) Constructor {
+	if constructor == nil {
+		return nil
+	}
+
+	return func(arguments ...interface{}) (interface{}, error) {
+		return constructor(context.Background(), arguments...)
+	}
+}
+
+// newContextRegistration returns a registry object for a given context-aware
+// object constructor.
+func newContextRegistration(constructor ContextConstructor) *registration {
+	return &registration{
+		constructor:        withoutContext(constructor),
+		contextConstructor: constructor,
+		function:           constructor,
+	}
+}
+
+// contextConstructorOf returns a context-aware object constructor of a given
+// registry object.
+func contextConstructorOf(object interface{}) ContextConstructor {
+	if r, ok := object.(*registration); ok {
+		if r.contextConstructor != nil {
+			return r.contextConstructor
+		}
+
+		return withContext(r.constructor)
+	}
+
+	return withContext(toConstructor(object))
+}
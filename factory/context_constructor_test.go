@@ -0,0 +1,225 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type connection struct {
+	closed int32
+}
+
+func (c *connection) Close() error {
+	atomic.StoreInt32(&c.closed, 1)
+	return nil
+}
+
+func Dial(ctx context.Context, arguments ...interface{}) (interface{}, error) {
+	select {
+	case <-time.After(arguments[0].(time.Duration)):
+		return &connection{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestCreateContext(test *testing.T) {
+	f := factory.New()
+
+	assert.NoError(test, f.AddContext("dial", Dial))
+	assert.Error(test, f.AddContext("dial", Dial))
+
+	object, err := f.CreateContext(context.Background(), "dial", time.Duration(0))
+
+	assert.NoError(test, err)
+	assert.IsType(test, &connection{}, object)
+
+	object, err = f.Create("dial", time.Duration(0))
+
+	assert.NoError(test, err)
+	assert.IsType(test, &connection{}, object)
+
+	constructor, err := f.Get("dial")
+
+	assert.NoError(test, err)
+
+	object, err = constructor(time.Duration(0))
+
+	assert.NoError(test, err)
+	assert.IsType(test, &connection{}, object)
+}
+
+func TestCreateContextDeadline(test *testing.T) {
+	f := factory.New().SetContext("dial", Dial)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := f.CreateContext(ctx, "dial", time.Hour)
+
+	assert.True(test, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestCreateContextIgnoringConstructor(test *testing.T) {
+	created := make(chan *connection, 1)
+	release := make(chan struct{})
+
+	f := factory.New().Set("slow", func(...interface{}) (interface{}, error) {
+		<-release
+
+		c := &connection{}
+		created <- c
+
+		return c, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := f.CreateContext(ctx, "slow")
+
+	assert.True(test, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(test, int64(time.Since(start)), int64(time.Second))
+
+	close(release)
+
+	c := <-created
+
+	assert.Eventually(test, func() bool {
+		return atomic.LoadInt32(&c.closed) == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestCreateContextPanic(test *testing.T) {
+	f := factory.New().Set("constructor", func(...interface{}) (interface{}, error) {
+		panic("failure")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	assert.PanicsWithValue(test, "failure", func() { _, _ = f.CreateContext(ctx, "constructor") })
+	assert.PanicsWithValue(test, "failure", func() { _, _ = f.CreateContext(context.Background(), "constructor") })
+}
+
+func TestCreateContextCancelled(test *testing.T) {
+	var calls int32
+
+	f := factory.New().Set("constructor", func(...interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "object", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := f.CreateContext(ctx, "constructor")
+
+	assert.True(test, errors.Is(err, context.Canceled))
+	assert.Equal(test, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestCreatesContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := []string{}
+
+	f := factory.New().
+		Set("first", func(...interface{}) (interface{}, error) {
+			calls = append(calls, "first")
+			return "first", nil
+		}).
+		SetContext("cancel", func(context.Context, ...interface{}) (interface{}, error) {
+			calls = append(calls, "cancel")
+			cancel()
+
+			return "cancel", nil
+		}).
+		Set("last", func(...interface{}) (interface{}, error) {
+			calls = append(calls, "last")
+			return "last", nil
+		})
+
+	objects, err := f.CreatesContext(ctx, []string{"first", "cancel", "last"})
+
+	assert.True(test, errors.Is(err, context.Canceled))
+	assert.Equal(test, "first", objects[0])
+	assert.NotContains(test, objects, "last")
+	assert.Equal(test, []string{"first", "cancel"}, calls)
+
+	objects, err = f.Creates([]string{"first", "cancel", "last"})
+
+	assert.NoError(test, err)
+	assert.Equal(test, []interface{}{"first", "cancel", "last"}, objects)
+}
+
+func TestCreateContextSingleton(test *testing.T) {
+	var calls int32
+
+	f := factory.New().SetWithLifetime("singleton", func(...interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return &connection{}, nil
+	}, factory.Singleton)
+
+	first, err := f.CreateContext(context.Background(), "singleton")
+	assert.NoError(test, err)
+
+	second, err := f.Create("singleton")
+	assert.NoError(test, err)
+
+	assert.Same(test, first, second)
+	assert.Equal(test, int32(1), calls)
+}
+
+func TestCreateContextDiff(test *testing.T) {
+	a := factory.New().SetContext("dial", Dial)
+	b := factory.New().SetContext("dial", Dial)
+
+	assert.True(test, factory.Diff(a, b, nil).IsEmpty())
+
+	b.SetContext("dial", func(context.Context, ...interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	assert.Equal(test, registry.Names{"dial"}, factory.Diff(a, b, nil).Changed)
+}
+
+func TestCreateContextGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	assert.NoError(test, factory.AddContext("dial", Dial))
+	factory.SetContext("other", Dial)
+
+	object, err := factory.CreateContext(context.Background(), "dial", time.Duration(0))
+
+	assert.NoError(test, err)
+	assert.IsType(test, &connection{}, object)
+
+	objects, err := factory.CreatesContext(context.Background(), []string{"dial", "other"}, time.Duration(0))
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, 2)
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+func TestContextWithOverlay(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", func(...interface{}) (interface{}, error) {
+		return "global", nil
+	})
+
+	ctx := factory.WithOverlay(context.Background(), factory.Constructors{
+		"constructor": func(...interface{}) (interface{}, error) {
+			return "overlay", nil
+		},
+		"double": Constructor,
+	})
+
+	lookup := factory.FromContext(ctx)
+
+	object, err := lookup.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "overlay", object)
+
+	object, err = factory.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+
+	object, err = factory.FromContext(context.Background()).Create("constructor")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+
+	assert.True(test, lookup.IsExist("double"))
+	assert.True(test, lookup.IsExist("constructor"))
+	assert.False(test, lookup.IsExist("unknown"))
+	assert.False(test, factory.IsExist("double"))
+
+	constructor, err := lookup.Get("double")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, constructor)
+
+	_, err = lookup.Get("unknown")
+	assert.Error(test, err)
+}
+
+func TestContextCreates(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructorA", Constructor)
+
+	ctx := factory.WithOverlay(context.Background(), factory.Constructors{
+		"constructorB": Constructor,
+		"constructorC": ConstructorError,
+	})
+
+	objects, err := factory.FromContext(ctx).Creates([]string{"constructorA", "constructorB"})
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, 2)
+
+	objects, err = factory.FromContext(ctx).Creates([]string{"constructorA", "constructorC"})
+
+	assert.Error(test, err)
+	assert.Len(test, objects, 1)
+}
+
+func TestContextNestedOverlay(test *testing.T) {
+	defer factory.RemoveAll()
+
+	parent := factory.WithOverlay(context.Background(), factory.Constructors{
+		"constructor": ConstructorError,
+		"tenant":      Constructor,
+	})
+
+	child := factory.WithOverlay(parent, factory.Constructors{
+		"constructor": Constructor,
+	})
+
+	_, err := factory.FromContext(child).Create("constructor")
+	assert.NoError(test, err)
+
+	_, err = factory.FromContext(child).Create("tenant")
+	assert.NoError(test, err)
+
+	_, err = factory.FromContext(parent).Create("constructor")
+	assert.Error(test, err)
+}
+
+func TestContextLookupContext(test *testing.T) {
+	defer factory.RemoveAll()
+
+	type key struct{}
+
+	factory.SetContext("context", func(ctx context.Context, arguments ...interface{}) (interface{}, error) {
+		return ctx.Value(key{}) != nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx = factory.WithOverlay(ctx, factory.Constructors{
+		"constructor": Constructor,
+	})
+
+	object, err := factory.FromContext(ctx).Create("context")
+
+	assert.NoError(test, err)
+	assert.Equal(test, true, object)
+
+	object, err = factory.Lookup{}.Create("context")
+
+	assert.NoError(test, err)
+	assert.Equal(test, false, object)
+
+	cancel()
+
+	_, err = factory.FromContext(ctx).Create("context")
+	assert.True(test, errors.Is(err, context.Canceled))
+
+	_, err = factory.FromContext(ctx).Create("constructor")
+	assert.True(test, errors.Is(err, context.Canceled))
+
+	objects, err := factory.FromContext(ctx).Creates([]string{"constructor", "context"})
+
+	assert.Error(test, err)
+	assert.Empty(test, objects)
+}
@@ -15,6 +15,11 @@
 package factory
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
 	"gitlab.com/tymonx/go-error/rterror"
 	"gitlab.com/tymonx/go-patterns/registry"
 )
@@ -24,29 +29,106 @@ type Names []string
 
 // Factory defines a factory instance that can create registered object types.
 type Factory struct {
-	registry registry.Registry
+	registry  registry.Registry
+	validator registry.Validator
 }
 
-// New creates a new factory instance.
-func New() *Factory {
+// New creates a new factory instance. Given registry options are applied to
+// the registry of object constructors.
+func New(options ...registry.Option) *Factory {
 	return &Factory{
-		registry: *registry.New(),
+		registry: *registry.New(options...),
 	}
 }
 
+// NewConstrained creates a new factory instance that accepts only created
+// objects passing a given validator.
+func NewConstrained(validator registry.Validator, options ...registry.Option) *Factory {
+	f := New(options...)
+	f.validator = validator
+
+	return f
+}
+
+// NewTyped creates a new factory instance that accepts only created objects
+// assignable to a given type.
+func NewTyped(kind reflect.Type, options ...registry.Option) *Factory {
+	return NewConstrained(registry.AssignableTo(kind), options...)
+}
+
+// Configure applies given registry options to the registry of object constructors.
+func (f *Factory) Configure(options ...registry.Option) *Factory {
+	f.registry.Configure(options...)
+	return f
+}
+
 // Create creates a new object based on given name.
 func (f *Factory) Create(name string, arguments ...interface{}) (object interface{}, err error) {
-	var constructor Constructor
+	start := time.Now()
+	object, err = f.create(context.Background(), nil, name, arguments...)
+	f.record(name, start, err)
+
+	return object, err
+}
+
+// record records object creation metrics. Metrics are recorded under
+// a registry key and only for registered objects, so names that were not
+// registered do not grow recorded metrics. They are recorded by registry
+// as lookup misses.
+func (f *Factory) record(name string, start time.Time, err error) {
+	key, keyErr := f.registry.Key(name)
+
+	if (keyErr == nil) && f.registry.IsExist(name) {
+		f.registry.Recorder().Create(key, time.Since(start), err)
+	}
+}
 
-	if constructor, err = f.Get(name); err != nil {
+// create creates a new object based on given name without recording metrics.
+// Scoped objects are created within a given scope.
+func (f *Factory) create(ctx context.Context, scope *Scope, name string, arguments ...interface{}) (object interface{}, err error) {
+	if scope != nil && scope.isClosed() {
+		return nil, rterror.New("scope was closed", name)
+	}
+
+	if object, err = f.registry.Get(name); err != nil {
 		return nil, err
 	}
 
+	if r, ok := object.(*registration); ok {
+		if arguments, err = r.schema.apply(name, arguments); err != nil {
+			return nil, err
+		}
+
+		switch r.lifetime {
+		case Singleton:
+			return r.singleton(ctx, f, name, arguments...)
+		case Scoped:
+			if scope == nil {
+				return nil, rterror.New("scoped object can be created only within a scope", name)
+			}
+
+			return scope.scoped(ctx, f, name, r, arguments...)
+		}
+	}
+
+	return f.construct(ctx, name, contextConstructorOf(object), arguments...)
+}
+
+// construct creates a new object with a given constructor.
+func (f *Factory) construct(ctx context.Context, name string, constructor ContextConstructor,
+	arguments ...interface{}) (object interface{}, err error) {
 	if constructor == nil {
 		return nil, rterror.New("constructor cannot be nil", name)
 	}
 
-	if object, err = constructor(arguments...); err != nil {
+	if object, err = invoke(ctx, constructor, arguments); err != nil {
+		var invalid *InvalidArgumentsError
+
+		if errors.As(err, &invalid) && (invalid.Name == "") {
+			invalid.Name = name
+			return nil, rterror.New(invalid.Error(), invalid)
+		}
+
 		return nil, rterror.New("cannot create object", name, err)
 	}
 
@@ -54,6 +136,12 @@ func (f *Factory) Create(name string, arguments ...interface{}) (object interfac
 		return nil, rterror.New("object was not created", name)
 	}
 
+	if f.validator != nil {
+		if err = f.validator(name, object); err != nil {
+			return nil, rterror.New("object was rejected", name, err)
+		}
+	}
+
 	return object, nil
 }
 
@@ -91,17 +179,34 @@ func (f *Factory) Adds(constructors Constructors) error {
 }
 
 // Set sets an object constructor with a given unique id to registry.
+// It panics if name or constructor does not pass registry constraints. Use
+// TrySet to get an error instead.
 func (f *Factory) Set(name string, constructor Constructor) *Factory {
 	f.registry.Set(name, constructor)
 	return f
 }
 
 // Sets sets object constructors with given unique ids to registry.
+// It panics without setting anything if any name or constructor does not pass
+// registry constraints. Use TrySets to get an error instead.
 func (f *Factory) Sets(constructors Constructors) *Factory {
 	f.registry.Sets(toObjects(constructors))
 	return f
 }
 
+// TrySet sets an object constructor with a given unique id to registry. It
+// returns an error if name or constructor does not pass registry constraints.
+func (f *Factory) TrySet(name string, constructor Constructor) error {
+	return f.registry.TrySet(name, constructor)
+}
+
+// TrySets sets object constructors with given unique ids to registry. It
+// returns an error without setting anything if any name or constructor does
+// not pass registry constraints.
+func (f *Factory) TrySets(constructors Constructors) error {
+	return f.registry.TrySets(toObjects(constructors))
+}
+
 // Get returns registered object constructor by given name.
 func (f *Factory) Get(name string) (constructor Constructor, err error) {
 	var object interface{}
@@ -124,6 +229,19 @@ func (f *Factory) GetAll() Constructors {
 	return toConstructors(f.registry.GetAll())
 }
 
+// Entries returns all registered object constructors with their registration
+// details sorted by name. Context-aware object constructors and functions
+// added by AddFunc are returned as registered, not as object constructors.
+func (f *Factory) Entries() []registry.Entry {
+	entries := f.registry.Entries()
+
+	for i := range entries {
+		entries[i].Object = functionOf(entries[i].Object)
+	}
+
+	return entries
+}
+
 // Remove removes registered object constructor.
 func (f *Factory) Remove(name string) *Factory {
 	f.registry.Remove(name)
@@ -163,6 +281,10 @@ func (f *Factory) Size() int {
 }
 
 func toConstructor(object interface{}) Constructor {
+	if r, ok := object.(*registration); ok {
+		return r.constructor
+	}
+
 	return object.(Constructor)
 }
 
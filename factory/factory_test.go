@@ -15,10 +15,15 @@
 package factory_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-error/rterror"
 	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/metrics"
+	"gitlab.com/tymonx/go-patterns/registry"
 )
 
 func TestFactoryNew(test *testing.T) {
@@ -343,3 +348,143 @@ func TestFactoryRemoveAll(test *testing.T) {
 
 	assert.Empty(test, constructors)
 }
+
+func TestFactoryNewConstrained(test *testing.T) {
+	f := factory.NewConstrained(func(name string, object interface{}) error {
+		return rterror.New("rejected", name)
+	})
+
+	assert.NoError(test, f.Add("constructor", Constructor))
+
+	object, err := f.Create("constructor")
+
+	assert.Error(test, err)
+	assert.Nil(test, object)
+}
+
+func TestFactoryNewTyped(test *testing.T) {
+	f := factory.NewTyped(reflect.TypeOf(new(struct{})))
+
+	assert.NoError(test, f.Adds(factory.Constructors{
+		"constructor": Constructor,
+		"string": func(...interface{}) (interface{}, error) {
+			return "string", nil
+		},
+	}))
+
+	object, err := f.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, object)
+
+	object, err = f.Create("string")
+
+	assert.Error(test, err)
+	assert.Nil(test, object)
+}
+
+func TestFactoryTrySet(test *testing.T) {
+	f := factory.New(registry.WithNameValidator(registry.Reserved("default")))
+
+	assert.NoError(test, f.TrySet("constructor", Constructor))
+	assert.Error(test, f.TrySet("default", Constructor))
+	assert.Error(test, f.TrySets(factory.Constructors{
+		"constructorA": Constructor,
+		"default":      Constructor,
+	}))
+
+	assert.Equal(test, 1, f.Size())
+}
+
+func TestFactoryNewWithOptions(test *testing.T) {
+	f := factory.New(registry.WithNormalizer(registry.FoldCase))
+
+	assert.NoError(test, f.Add("Constructor", Constructor))
+	assert.True(test, f.IsExist("CONSTRUCTOR"))
+
+	object, err := f.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, object)
+}
+
+func TestFactoryCreateMetrics(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	f := factory.New(registry.WithRecorder(recorder))
+
+	assert.NoError(test, f.Adds(factory.Constructors{
+		"constructor": Constructor,
+		"error":       ConstructorError,
+	}))
+
+	_, err := f.Create("constructor")
+	assert.NoError(test, err)
+
+	_, err = f.Create("constructor")
+	assert.NoError(test, err)
+
+	_, err = f.Create("error")
+	assert.Error(test, err)
+
+	assert.Equal(test, int64(2), recorder.Creates("constructor"))
+	assert.Zero(test, recorder.CreateErrors("constructor"))
+	assert.Equal(test, int64(1), recorder.Creates("error"))
+	assert.Equal(test, int64(1), recorder.CreateErrors("error"))
+	assert.Equal(test, int64(2), recorder.Adds())
+}
+
+func TestFactoryCreateMetricsKeys(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	f := factory.New(registry.WithRecorder(recorder), registry.WithNormalizer(registry.FoldCase)).
+		Set("constructor", Constructor)
+
+	_, err := f.Create("CONSTRUCTOR")
+	assert.NoError(test, err)
+
+	_, err = f.Create("unknown")
+	assert.Error(test, err)
+
+	assert.Equal(test, int64(1), recorder.Creates("constructor"))
+	assert.Zero(test, recorder.Creates("CONSTRUCTOR"))
+	assert.Zero(test, recorder.Creates("unknown"))
+	assert.Zero(test, recorder.CreateErrors("unknown"))
+	assert.Equal(test, int64(1), recorder.Misses())
+}
+
+func TestFactoryEntries(test *testing.T) {
+	f := factory.New()
+
+	assert.NoError(test, f.Add("constructor", Constructor))
+
+	entries := f.Entries()
+
+	assert.Len(test, entries, 1)
+	assert.Equal(test, "constructor", entries[0].Name)
+	assert.Contains(test, entries[0].Source, "factory_test.go:")
+}
+
+func TestFactorySuggestions(test *testing.T) {
+	f := factory.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).
+		Set("postgres", Constructor)
+
+	var notRegistered *registry.NotRegisteredError
+
+	_, err := f.Create("postgress")
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, registry.Names{"postgres"}, notRegistered.Suggestions)
+
+	_, err = f.Get("postgress")
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Contains(test, err.Error(), "did you mean postgres?")
+
+	f.Configure(registry.WithSuggestionDistance(0))
+
+	_, err = f.Create("postgress")
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Empty(test, notRegistered.Suggestions)
+}
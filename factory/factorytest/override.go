@@ -0,0 +1,55 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factorytest
+
+import (
+	"testing"
+
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/internal/testowner"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+var gOwner = testowner.New("factorytest", "global factory") // nolint: gochecknoglobals
+
+// Override replaces the global factory instance with an isolated one holding
+// only given constructors for the duration of a test. The previous instance is
+// restored when the test and all its subtests complete.
+//
+// The global factory is shared by the whole process, so tests overriding it
+// must not run in parallel with each other. Override fails the test when the
+// global factory is already overridden by another running test that is not
+// its parent. Nested overrides within the same test or its subtests are allowed.
+func Override(test testing.TB, constructors factory.Constructors, options ...registry.Option) {
+	test.Helper()
+
+	instance := factory.New(options...)
+
+	if err := instance.Adds(constructors); err != nil {
+		test.Fatalf("factorytest: cannot override global factory: %v", err)
+		return
+	}
+
+	if !gOwner.Acquire(test) {
+		return
+	}
+
+	previous := factory.Replace(instance)
+
+	test.Cleanup(func() {
+		factory.Replace(previous)
+		gOwner.Release()
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factorytest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/factory/factorytest"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type recorder struct {
+	testing.TB
+	failure  string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, arguments ...interface{}) {
+	r.failure = fmt.Sprintf(format, arguments...)
+}
+
+func (r *recorder) Cleanup(cleanup func()) {
+	r.cleanups = append(r.cleanups, cleanup)
+}
+
+func constructor(value string) factory.Constructor {
+	return func(...interface{}) (interface{}, error) {
+		return value, nil
+	}
+}
+
+func TestOverride(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", constructor("global"))
+
+	test.Run("override", func(test *testing.T) {
+		factorytest.Override(test, factory.Constructors{
+			"constructor": constructor("overridden"),
+			"other":       constructor("overridden"),
+		})
+
+		object, err := factory.Create("constructor")
+
+		assert.NoError(test, err)
+		assert.Equal(test, "overridden", object)
+		assert.Equal(test, 2, factory.Size())
+
+		factory.Set("leaked", constructor("value"))
+	})
+
+	object, err := factory.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+	assert.False(test, factory.IsExist("other"))
+	assert.False(test, factory.IsExist("leaked"))
+}
+
+func TestOverrideInvalidConstructors(test *testing.T) {
+	fake := &recorder{}
+
+	factorytest.Override(fake, factory.Constructors{"default": constructor("value")},
+		registry.WithNameValidator(registry.Reserved("default")))
+
+	assert.Contains(test, fake.failure, "cannot override")
+	assert.Empty(test, fake.cleanups)
+}
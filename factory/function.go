@@ -0,0 +1,222 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"reflect"
+	"strconv"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// errorType defines the reflection type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem() // nolint: gochecknoglobals
+
+// Func returns an object constructor calling a given function. The function
+// can have any typed parameters, including variadic ones, and it must return
+// an object and optionally an error. Arguments passed to the object
+// constructor are assigned to function parameters in order. Numeric arguments
+// are converted to numeric parameter types if their values are preserved.
+func Func(function interface{}) (Constructor, error) {
+	value := reflect.ValueOf(function)
+
+	if (value.Kind() != reflect.Func) || value.IsNil() {
+		return nil, rterror.New("constructor is not a function", typeString(function))
+	}
+
+	kind := value.Type()
+
+	if (kind.NumOut() < 1) || (kind.NumOut() > 2) || (kind.Out(0) == errorType) ||
+		((kind.NumOut() == 2) && (kind.Out(1) != errorType)) {
+		return nil, rterror.New("constructor function must return an object and optionally an error", kind.String())
+	}
+
+	return func(arguments ...interface{}) (interface{}, error) {
+		values, err := convertArguments(kind, arguments)
+
+		if err != nil {
+			return nil, err
+		}
+
+		results := value.Call(values)
+
+		if (len(results) == 2) && !results[1].IsNil() {
+			return nil, results[1].Interface().(error)
+		}
+
+		return results[0].Interface(), nil
+	}, nil
+}
+
+// AddFunc adds an object constructor calling a given function with a given
+// unique id to registry. See Func for supported functions.
+func (f *Factory) AddFunc(name string, function interface{}) error {
+	constructor, err := Func(function)
+
+	if err != nil {
+		return err
+	}
+
+	return f.registry.Add(name, &registration{
+		constructor: constructor,
+		function:    function,
+	})
+}
+
+// SetFunc sets an object constructor calling a given function with a given
+// unique id to registry. See Func for supported functions.
+func (f *Factory) SetFunc(name string, function interface{}) *Factory {
+	constructor, err := Func(function)
+
+	if err != nil {
+		panic(err)
+	}
+
+	f.registry.Set(name, &registration{
+		constructor: constructor,
+		function:    function,
+	})
+
+	return f
+}
+
+// convertArguments converts given arguments to parameter types of a given
+// function type.
+func convertArguments(kind reflect.Type, arguments []interface{}) ([]reflect.Value, error) {
+	count := kind.NumIn()
+
+	if kind.IsVariadic() {
+		if len(arguments) < (count - 1) {
+			return nil, invalidArguments(&InvalidArgumentsError{
+				Position: len(arguments),
+				Reason:   "too few arguments, expected at least " + strconv.Itoa(count-1),
+			})
+		}
+	} else if len(arguments) != count {
+		position := count
+
+		if len(arguments) < count {
+			position = len(arguments)
+		}
+
+		return nil, invalidArguments(&InvalidArgumentsError{
+			Position: position,
+			Reason:   "invalid number of arguments, expected " + strconv.Itoa(count),
+		})
+	}
+
+	values := make([]reflect.Value, len(arguments))
+
+	for index, argument := range arguments {
+		var parameter reflect.Type
+
+		if kind.IsVariadic() && (index >= (count - 1)) {
+			parameter = kind.In(count - 1).Elem()
+		} else {
+			parameter = kind.In(index)
+		}
+
+		value, ok := convertArgument(argument, parameter)
+
+		if !ok {
+			return nil, invalidArguments(&InvalidArgumentsError{
+				Position: index,
+				Reason:   "cannot use " + typeString(argument) + " as " + parameter.String(),
+			})
+		}
+
+		values[index] = value
+	}
+
+	return values, nil
+}
+
+// convertArgument converts a given argument to a given parameter type.
+func convertArgument(argument interface{}, parameter reflect.Type) (reflect.Value, bool) {
+	if argument == nil {
+		switch parameter.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(parameter), true
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	value := reflect.ValueOf(argument)
+
+	if value.Type().AssignableTo(parameter) {
+		return value, true
+	}
+
+	if !isNumeric(value.Kind()) || !isNumeric(parameter.Kind()) {
+		return reflect.Value{}, false
+	}
+
+	converted := value.Convert(parameter)
+
+	if isFloat(value.Kind()) && isFloat(parameter.Kind()) {
+		return converted, !converted.OverflowFloat(value.Float())
+	}
+
+	if converted.Convert(value.Type()).Interface() != argument {
+		return reflect.Value{}, false
+	}
+
+	// Round trip keeps bits between signed and unsigned integers of the same
+	// size, like -1 and 255 for int8 and uint8, so sign must be checked as well
+	if isNegative(value) != isNegative(converted) {
+		return reflect.Value{}, false
+	}
+
+	return converted, true
+}
+
+// isNegative returns true if a given numeric value is negative.
+func isNegative(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() < 0
+	default:
+		return false
+	}
+}
+
+// isNumeric returns true if a given kind is an integer or a floating-point number kind.
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isFloat returns true if a given kind is a floating-point number kind.
+func isFloat(kind reflect.Kind) bool {
+	return (kind == reflect.Float32) || (kind == reflect.Float64)
+}
+
+// typeString returns type name of a given object.
+func typeString(object interface{}) string {
+	if object == nil {
+		return "nil"
+	}
+
+	return reflect.TypeOf(object).String()
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+type server struct {
+	address string
+	port    int
+	timeout time.Duration
+	tags    []string
+}
+
+func newServer(address string, port int, timeout time.Duration) *server {
+	return &server{address: address, port: port, timeout: timeout}
+}
+
+func newTagged(address string, tags ...string) (*server, error) {
+	if address == "" {
+		return nil, errors.New("empty address")
+	}
+
+	return &server{address: address, tags: tags}, nil
+}
+
+func TestFuncInvalid(test *testing.T) {
+	for _, function := range []interface{}{
+		nil,
+		"function",
+		(func() *server)(nil),
+		func() {},
+		func() error { return nil },
+		func() (*server, string) { return nil, "" },
+		func() (*server, error, error) { return nil, nil, nil },
+	} {
+		_, err := factory.Func(function)
+		assert.Error(test, err)
+	}
+}
+
+func TestFuncCreate(test *testing.T) {
+	f := factory.New()
+
+	assert.NoError(test, f.AddFunc("server", newServer))
+	assert.Error(test, f.AddFunc("server", newServer))
+	assert.Error(test, f.AddFunc("invalid", 1))
+
+	object, err := f.Create("server", "localhost", 8080, time.Second)
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080, timeout: time.Second}, object)
+
+	object, err = f.Create("server", "localhost", uint16(8080), 5)
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080, timeout: 5}, object)
+}
+
+func TestFuncArgumentErrors(test *testing.T) {
+	f := factory.New().SetFunc("server", newServer)
+
+	_, err := f.Create("server", "localhost", 8080)
+	assert.Error(test, err)
+
+	_, err = f.Create("server", "localhost", 8080, time.Second, 1)
+	assert.Error(test, err)
+
+	_, err = f.Create("server", 8080, "localhost", time.Second)
+	assert.Error(test, err)
+
+	_, err = f.Create("server", "localhost", 80.5, time.Second)
+	assert.Error(test, err)
+
+	_, err = f.Create("server", nil, 8080, time.Second)
+	assert.Error(test, err)
+
+	assert.Panics(test, func() {
+		f.SetFunc("invalid", func() {})
+	})
+}
+
+func TestFuncVariadic(test *testing.T) {
+	f := factory.New().SetFunc("tagged", newTagged)
+
+	object, err := f.Create("tagged", "localhost")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "localhost", object.(*server).address)
+	assert.Empty(test, object.(*server).tags)
+
+	object, err = f.Create("tagged", "localhost", "a", "b")
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", tags: []string{"a", "b"}}, object)
+
+	_, err = f.Create("tagged")
+	assert.Error(test, err)
+
+	_, err = f.Create("tagged", "localhost", "a", 1)
+	assert.Error(test, err)
+
+	_, err = f.Create("tagged", "")
+	assert.Error(test, err)
+}
+
+func TestFuncConversions(test *testing.T) {
+	f := factory.New().
+		SetFunc("int8", func(value int8) int8 { return value }).
+		SetFunc("float32", func(value float32) float32 { return value }).
+		SetFunc("reader", func(reader io.Reader) interface{} { return reader })
+
+	object, err := f.Create("int8", 100)
+
+	assert.NoError(test, err)
+	assert.Equal(test, int8(100), object)
+
+	_, err = f.Create("int8", 300)
+	assert.Error(test, err)
+
+	_, err = f.Create("int8", -1.5)
+	assert.Error(test, err)
+
+	object, err = f.Create("float32", 0.1)
+
+	assert.NoError(test, err)
+	assert.Equal(test, float32(0.1), object)
+
+	_, err = f.Create("float32", 1e300)
+	assert.Error(test, err)
+
+	reader := strings.NewReader("")
+
+	object, err = f.Create("reader", reader)
+
+	assert.NoError(test, err)
+	assert.Same(test, reader, object)
+
+	_, err = f.Create("reader", nil)
+	assert.Error(test, err)
+}
+
+func TestFuncSignConversions(test *testing.T) {
+	f := factory.New().
+		SetFunc("uint", func(value uint) uint { return value }).
+		SetFunc("uint8", func(value uint8) uint8 { return value }).
+		SetFunc("int32", func(value int32) int32 { return value }).
+		SetFunc("int64", func(value int64) int64 { return value })
+
+	for _, testCase := range []struct {
+		name     string
+		argument interface{}
+		expected interface{}
+	}{
+		{name: "uint", argument: 1, expected: uint(1)},
+		{name: "uint", argument: -1},
+		{name: "uint", argument: int64(math.MinInt64)},
+		{name: "uint", argument: -1.0},
+		{name: "uint8", argument: int8(127), expected: uint8(127)},
+		{name: "uint8", argument: int8(-1)},
+		{name: "uint8", argument: int16(-256)},
+		{name: "int32", argument: uint32(math.MaxInt32), expected: int32(math.MaxInt32)},
+		{name: "int32", argument: uint32(math.MaxUint32)},
+		{name: "int32", argument: uint32(math.MaxInt32 + 1)},
+		{name: "int64", argument: uint64(math.MaxInt64), expected: int64(math.MaxInt64)},
+		{name: "int64", argument: uint64(math.MaxUint64)},
+		{name: "int64", argument: uint(math.MaxInt64 + 1)},
+	} {
+		object, err := f.Create(testCase.name, testCase.argument)
+
+		if testCase.expected == nil {
+			assert.Error(test, err, "%s %T(%v)", testCase.name, testCase.argument, testCase.argument)
+			assert.Nil(test, object)
+		} else {
+			assert.NoError(test, err, "%s %T(%v)", testCase.name, testCase.argument, testCase.argument)
+			assert.Equal(test, testCase.expected, object)
+		}
+	}
+}
+
+func TestFuncGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	assert.NoError(test, factory.AddFunc("server", newServer))
+	factory.SetFunc("tagged", newTagged)
+
+	object, err := factory.Create("tagged", "localhost", "a")
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", tags: []string{"a"}}, object)
+}
+
+func TestFuncEntries(test *testing.T) {
+	create := func(ctx context.Context, arguments ...interface{}) (interface{}, error) {
+		return ctx, nil
+	}
+
+	f := factory.New().SetFunc("server", newServer).SetContext("context", create)
+
+	entries := f.Entries()
+
+	assert.Len(test, entries, 2)
+	assert.Equal(test, reflect.ValueOf(create).Pointer(), reflect.ValueOf(entries[0].Object).Pointer())
+	assert.Equal(test, reflect.ValueOf(newServer).Pointer(), reflect.ValueOf(entries[1].Object).Pointer())
+}
+
+func TestFuncInvalidArguments(test *testing.T) {
+	f := factory.New().SetFunc("server", newServer)
+
+	_, err := f.Create("server", "localhost", "8080", time.Second)
+
+	var invalid *factory.InvalidArgumentsError
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, "server", invalid.Name)
+	assert.Equal(test, 1, invalid.Position)
+	assert.Contains(test, err.Error(), "invalid argument 1 of server: cannot use string as int")
+
+	_, err = f.Create("server", "localhost")
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, 1, invalid.Position)
+}
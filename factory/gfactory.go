@@ -15,154 +15,229 @@
 package factory
 
 import (
+	"context"
 	"sync"
 
-	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
 )
 
-var gInstance *Factory // nolint: gochecknoglobals
-var gGuard guard.Guard // nolint: gochecknoglobals
-var gOnce sync.Once    // nolint: gochecknoglobals
+var gDefault *Global // nolint: gochecknoglobals
+var gOnce sync.Once  // nolint: gochecknoglobals
 
-// Create creates a new object based on given name.
-func Create(name string, arguments ...interface{}) (object interface{}, err error) {
-	gGuard.Read(func() {
-		object, err = getInstance().Create(name, arguments...)
-	})
+// Configure applies given registry options to the registry of object constructors.
+func Configure(options ...registry.Option) {
+	getDefault().Configure(options...)
+}
 
-	return object, err
+// Create creates a new object based on given name.
+func Create(name string, arguments ...interface{}) (interface{}, error) {
+	return getDefault().Create(name, arguments...)
 }
 
 // Creates creates a list of new objects based on given names.
-func Creates(names []string, arguments ...interface{}) (objects []interface{}, err error) {
-	gGuard.Read(func() {
-		objects, err = getInstance().Creates(names, arguments...)
-	})
+func Creates(names []string, arguments ...interface{}) ([]interface{}, error) {
+	return getDefault().Creates(names, arguments...)
+}
 
-	return objects, err
+// CreateContext creates a new object based on given name with a given context.
+// See Factory.CreateContext for details.
+func CreateContext(ctx context.Context, name string, arguments ...interface{}) (interface{}, error) {
+	return getDefault().CreateContext(ctx, name, arguments...)
 }
 
-// Add adds a new constructor with a given unique id to factory.
-func Add(name string, constructor Constructor) (err error) {
-	gGuard.Write(func() {
-		err = getInstance().Add(name, constructor)
-	})
+// CreatesContext creates a list of new objects based on given names with
+// a given context. When the context is done, remaining objects are not created.
+func CreatesContext(ctx context.Context, names []string, arguments ...interface{}) ([]interface{}, error) {
+	return getDefault().CreatesContext(ctx, names, arguments...)
+}
 
-	return err
+// CreatesResults creates a list of new objects based on given names and
+// returns a result for every given name. See Factory.CreatesResults for details.
+func CreatesResults(names []string, arguments ...interface{}) Results {
+	return getDefault().CreatesResults(names, arguments...)
+}
+
+// CreatesParallel creates a list of new objects based on given names
+// concurrently. See Factory.CreatesParallel for details.
+func CreatesParallel(ctx context.Context, names []string, parallel Parallel,
+	arguments ...interface{}) ([]interface{}, error) {
+	return getDefault().CreatesParallel(ctx, names, parallel, arguments...)
+}
+
+// CreateNamed creates a new object based on given name with named options.
+// See Factory.CreateNamed for details.
+func CreateNamed(name string, options interface{}) (interface{}, error) {
+	return getDefault().CreateNamed(name, options)
+}
+
+// Add adds a new constructor with a given unique id to factory.
+func Add(name string, constructor Constructor) error {
+	return getDefault().Add(name, constructor)
 }
 
 // Adds adds new constructors with given unique ids to factory.
-func Adds(constructors Constructors) (err error) {
-	gGuard.Write(func() {
-		err = getInstance().Adds(constructors)
-	})
+func Adds(constructors Constructors) error {
+	return getDefault().Adds(constructors)
+}
+
+// AddContext adds a context-aware object constructor with a given unique id to factory.
+func AddContext(name string, constructor ContextConstructor) error {
+	return getDefault().AddContext(name, constructor)
+}
+
+// SetContext sets a context-aware object constructor with a given unique id to factory.
+func SetContext(name string, constructor ContextConstructor) {
+	getDefault().SetContext(name, constructor)
+}
 
-	return err
+// AddWithLifetime adds an object constructor with a given unique id and
+// object lifetime to factory.
+func AddWithLifetime(name string, constructor Constructor, lifetime Lifetime) error {
+	return getDefault().AddWithLifetime(name, constructor, lifetime)
 }
 
 // Set sets an constructor with a given unique id to factory.
+// It panics if name or constructor does not pass factory constraints.
 func Set(name string, constructor Constructor) {
-	gGuard.Write(func() {
-		getInstance().Set(name, constructor)
-	})
+	getDefault().Set(name, constructor)
 }
 
 // Sets sets constructors with given unique ids to factory.
+// It panics without setting anything if any name or constructor does not pass factory constraints.
 func Sets(constructors Constructors) {
-	gGuard.Write(func() {
-		getInstance().Sets(constructors)
-	})
+	getDefault().Sets(constructors)
 }
 
-// Get returns registered constructor by given name.
-func Get(name string) (constructor Constructor, err error) {
-	gGuard.Read(func() {
-		constructor, err = getInstance().Get(name)
-	})
+// TrySet sets an constructor with a given unique id to factory. It returns an
+// error if name or constructor does not pass factory constraints.
+func TrySet(name string, constructor Constructor) error {
+	return getDefault().TrySet(name, constructor)
+}
 
-	return constructor, err
+// TrySets sets constructors with given unique ids to factory. It returns an
+// error without setting anything if any name or constructor does not pass
+// factory constraints.
+func TrySets(constructors Constructors) error {
+	return getDefault().TrySets(constructors)
 }
 
-// Gets returns registered constructors by given names.
-func Gets(names []string) (constructors Constructors, err error) {
-	gGuard.Read(func() {
-		constructors, err = getInstance().Gets(names)
-	})
+// AddWithSchema adds an object constructor with a given unique id and
+// argument schema to factory.
+func AddWithSchema(name string, constructor Constructor, schema Schema) error {
+	return getDefault().AddWithSchema(name, constructor, schema)
+}
+
+// SetWithSchema sets an object constructor with a given unique id and
+// argument schema to factory.
+func SetWithSchema(name string, constructor Constructor, schema Schema) {
+	getDefault().SetWithSchema(name, constructor, schema)
+}
 
-	return constructors, err
+// SchemaOf returns argument schema of registered object constructor.
+func SchemaOf(name string) (Schema, error) {
+	return getDefault().SchemaOf(name)
+}
+
+// AddFunc adds an object constructor calling a given function with a given
+// unique id to factory. See Func for supported functions.
+func AddFunc(name string, function interface{}) error {
+	return getDefault().AddFunc(name, function)
+}
+
+// SetFunc sets an object constructor calling a given function with a given
+// unique id to factory. See Func for supported functions.
+func SetFunc(name string, function interface{}) {
+	getDefault().SetFunc(name, function)
+}
+
+// SetWithLifetime sets an object constructor with a given unique id and
+// object lifetime to factory.
+func SetWithLifetime(name string, constructor Constructor, lifetime Lifetime) {
+	getDefault().SetWithLifetime(name, constructor, lifetime)
+}
+
+// LifetimeOf returns object lifetime of registered object constructor.
+func LifetimeOf(name string) (Lifetime, error) {
+	return getDefault().LifetimeOf(name)
+}
+
+// NewScope creates a new scope of objects created by factory.
+func NewScope() *Scope {
+	return getDefault().NewScope()
+}
+
+// Get returns registered constructor by given name.
+func Get(name string) (Constructor, error) {
+	return getDefault().Get(name)
+}
+
+// Gets returns registered constructors by given names.
+func Gets(names []string) (Constructors, error) {
+	return getDefault().Gets(names)
 }
 
 // GetAll returns all registered constructors.
-func GetAll() (constructors Constructors) {
-	gGuard.Read(func() {
-		constructors = getInstance().GetAll()
-	})
+func GetAll() Constructors {
+	return getDefault().GetAll()
+}
 
-	return constructors
+// Entries returns all registered constructors with their registration details sorted by name.
+func Entries() []registry.Entry {
+	return getDefault().Entries()
 }
 
 // Remove removes registered constructor.
 func Remove(name string) {
-	gGuard.Write(func() {
-		getInstance().Remove(name)
-	})
+	getDefault().Remove(name)
 }
 
 // Removes removes registered constructor.
 func Removes(names []string) {
-	gGuard.Write(func() {
-		getInstance().Removes(names)
-	})
+	getDefault().Removes(names)
 }
 
 // RemoveAll removes all registered constructor.
 func RemoveAll() {
-	gGuard.Write(func() {
-		getInstance().RemoveAll()
-	})
+	getDefault().RemoveAll()
 }
 
 // IsExist returns true if constructor with given name was registered, otherwise it returns false.
-func IsExist(name string) (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsExist(name)
-	})
-
-	return value
+func IsExist(name string) bool {
+	return getDefault().IsExist(name)
 }
 
 // IsExists returns true if all object constructors with given names were registered, otherwise it returns false.
-func IsExists(names []string) (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsExists(names)
-	})
-
-	return value
+func IsExists(names []string) bool {
+	return getDefault().IsExists(names)
 }
 
 // IsEmpty returns true if there are no registered object constructors, otherwise it returns false.
-func IsEmpty() (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsEmpty()
-	})
-
-	return value
+func IsEmpty() bool {
+	return getDefault().IsEmpty()
 }
 
 // Size returns number of registered object constructors.
-func Size() (value int) {
-	gGuard.Read(func() {
-		value = getInstance().Size()
-	})
+func Size() int {
+	return getDefault().Size()
+}
 
-	return value
+// Replace replaces global factory instance with a given one and returns
+// the previous instance. A nil instance is replaced with a new empty factory.
+func Replace(instance *Factory) *Factory {
+	return getDefault().Replace(instance)
 }
 
-// getInstance returns global factory instance.
-func getInstance() *Factory {
+// getDefault returns default global factory.
+func getDefault() *Global {
 	gOnce.Do(func() {
-		gInstance = New()
+		gDefault = Named(DefaultGlobal)
 	})
 
-	return gInstance
+	return gDefault
+}
+
+// getInstance returns default global factory instance. Default global
+// factory must be locked.
+func getInstance() *Factory {
+	return getDefault().instance
 }
@@ -19,6 +19,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/metrics"
+	"gitlab.com/tymonx/go-patterns/registry"
 )
 
 func TestGlobalFactoryCreate(test *testing.T) {
@@ -150,6 +152,18 @@ func TestGlobalFactorySets(test *testing.T) {
 	assert.Len(test, factory.GetAll(), 2)
 }
 
+func TestGlobalFactoryTrySet(test *testing.T) {
+	defer factory.RemoveAll()
+
+	assert.NoError(test, factory.TrySet("constructor", Constructor))
+	assert.NoError(test, factory.TrySets(factory.Constructors{
+		"constructorA": Constructor,
+		"constructorB": Constructor,
+	}))
+
+	assert.Len(test, factory.GetAll(), 3)
+}
+
 func TestGlobalFactoryIsExist(test *testing.T) {
 	defer factory.RemoveAll()
 
@@ -327,3 +341,49 @@ func TestGlobalFactoryRemoveAll(test *testing.T) {
 
 	assert.Empty(test, constructors)
 }
+
+func TestGlobalFactoryConfigure(test *testing.T) {
+	defer factory.RemoveAll()
+	defer factory.Configure(registry.WithRecorder(nil))
+
+	recorder := metrics.NewLocalExpvar()
+
+	factory.Configure(registry.WithRecorder(recorder))
+
+	assert.NoError(test, factory.Add("constructor", Constructor))
+
+	_, err := factory.Create("constructor")
+
+	assert.NoError(test, err)
+	assert.Equal(test, int64(1), recorder.Creates("constructor"))
+}
+
+func TestGlobalFactoryEntries(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", Constructor)
+
+	entries := factory.Entries()
+
+	assert.Len(test, entries, 1)
+	assert.Equal(test, "constructor", entries[0].Name)
+	assert.Contains(test, entries[0].Source, "gfactory_test.go:")
+}
+
+func TestGlobalFactoryReplace(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", Constructor)
+
+	instance := factory.New()
+
+	previous := factory.Replace(instance)
+	assert.False(test, factory.IsExist("constructor"))
+
+	assert.Same(test, instance, factory.Replace(previous))
+	assert.True(test, factory.IsExist("constructor"))
+
+	previous = factory.Replace(nil)
+	assert.True(test, factory.IsEmpty())
+	factory.Replace(previous)
+}
@@ -0,0 +1,424 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"sort"
+
+	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// DefaultGlobal defines the name of the default global factory used by
+// package-level functions.
+const DefaultGlobal = "default"
+
+var gGlobals map[string]*Global // nolint: gochecknoglobals
+var gGlobalsGuard guard.Guard   // nolint: gochecknoglobals
+
+// Global defines a named process-wide factory instance safe for concurrent use.
+// Each global factory has its own guard and object constructors, so libraries
+// using different global factories never collide on object names.
+type Global struct {
+	name     string
+	guard    guard.Guard
+	instance *Factory
+}
+
+// Named returns a global factory with a given name. It is created on first use.
+func Named(name string) (global *Global) {
+	gGlobalsGuard.Read(func() {
+		global = gGlobals[name]
+	})
+
+	if global != nil {
+		return global
+	}
+
+	gGlobalsGuard.Write(func() {
+		if gGlobals == nil {
+			gGlobals = make(map[string]*Global)
+		}
+
+		if global = gGlobals[name]; global == nil {
+			global = &Global{
+				name:     name,
+				instance: New(),
+			}
+
+			gGlobals[name] = global
+		}
+	})
+
+	return global
+}
+
+// Globals returns sorted names of all created global factories.
+func Globals() (names []string) {
+	gGlobalsGuard.Read(func() {
+		names = make([]string, 0, len(gGlobals))
+
+		for name := range gGlobals {
+			names = append(names, name)
+		}
+	})
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Name returns global factory name.
+func (g *Global) Name() string {
+	return g.name
+}
+
+// Configure applies given registry options to the registry of object constructors.
+func (g *Global) Configure(options ...registry.Option) {
+	g.guard.Write(func() {
+		g.instance.Configure(options...)
+	})
+}
+
+// Create creates a new object based on given name.
+func (g *Global) Create(name string, arguments ...interface{}) (object interface{}, err error) {
+	g.guard.Read(func() {
+		object, err = g.instance.Create(name, arguments...)
+	})
+
+	return object, err
+}
+
+// Creates creates a list of new objects based on given names.
+func (g *Global) Creates(names []string, arguments ...interface{}) (objects []interface{}, err error) {
+	g.guard.Read(func() {
+		objects, err = g.instance.Creates(names, arguments...)
+	})
+
+	return objects, err
+}
+
+// CreateContext creates a new object based on given name with a given context.
+// See Factory.CreateContext for details.
+func (g *Global) CreateContext(ctx context.Context, name string, arguments ...interface{}) (object interface{}, err error) {
+	g.guard.Read(func() {
+		object, err = g.instance.CreateContext(ctx, name, arguments...)
+	})
+
+	return object, err
+}
+
+// CreatesContext creates a list of new objects based on given names with
+// a given context. When the context is done, remaining objects are not created.
+func (g *Global) CreatesContext(ctx context.Context, names []string,
+	arguments ...interface{}) (objects []interface{}, err error) {
+	g.guard.Read(func() {
+		objects, err = g.instance.CreatesContext(ctx, names, arguments...)
+	})
+
+	return objects, err
+}
+
+// CreatesResults creates a list of new objects based on given names and
+// returns a result for every given name. See Factory.CreatesResults for details.
+func (g *Global) CreatesResults(names []string, arguments ...interface{}) (results Results) {
+	g.guard.Read(func() {
+		results = g.instance.CreatesResults(names, arguments...)
+	})
+
+	return results
+}
+
+// CreatesParallel creates a list of new objects based on given names
+// concurrently. See Factory.CreatesParallel for details.
+func (g *Global) CreatesParallel(ctx context.Context, names []string, parallel Parallel,
+	arguments ...interface{}) (objects []interface{}, err error) {
+	g.guard.Read(func() {
+		objects, err = g.instance.CreatesParallel(ctx, names, parallel, arguments...)
+	})
+
+	return objects, err
+}
+
+// CreateNamed creates a new object based on given name with named options.
+// See Factory.CreateNamed for details.
+func (g *Global) CreateNamed(name string, options interface{}) (object interface{}, err error) {
+	g.guard.Read(func() {
+		object, err = g.instance.CreateNamed(name, options)
+	})
+
+	return object, err
+}
+
+// Add adds a new constructor with a given unique id to factory.
+func (g *Global) Add(name string, constructor Constructor) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.Add(name, constructor)
+	})
+
+	return err
+}
+
+// Adds adds new constructors with given unique ids to factory.
+func (g *Global) Adds(constructors Constructors) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.Adds(constructors)
+	})
+
+	return err
+}
+
+// AddContext adds a context-aware object constructor with a given unique id to factory.
+func (g *Global) AddContext(name string, constructor ContextConstructor) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.AddContext(name, constructor)
+	})
+
+	return err
+}
+
+// SetContext sets a context-aware object constructor with a given unique id to factory.
+func (g *Global) SetContext(name string, constructor ContextConstructor) {
+	g.guard.Write(func() {
+		g.instance.SetContext(name, constructor)
+	})
+}
+
+// AddWithLifetime adds an object constructor with a given unique id and
+// object lifetime to factory.
+func (g *Global) AddWithLifetime(name string, constructor Constructor, lifetime Lifetime) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.AddWithLifetime(name, constructor, lifetime)
+	})
+
+	return err
+}
+
+// Set sets an constructor with a given unique id to factory.
+// It panics if name or constructor does not pass factory constraints.
+func (g *Global) Set(name string, constructor Constructor) {
+	g.guard.Write(func() {
+		g.instance.Set(name, constructor)
+	})
+}
+
+// Sets sets constructors with given unique ids to factory.
+// It panics without setting anything if any name or constructor does not pass factory constraints.
+func (g *Global) Sets(constructors Constructors) {
+	g.guard.Write(func() {
+		g.instance.Sets(constructors)
+	})
+}
+
+// TrySet sets an constructor with a given unique id to factory. It returns an
+// error if name or constructor does not pass factory constraints.
+func (g *Global) TrySet(name string, constructor Constructor) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.TrySet(name, constructor)
+	})
+
+	return err
+}
+
+// TrySets sets constructors with given unique ids to factory. It returns an
+// error without setting anything if any name or constructor does not pass
+// factory constraints.
+func (g *Global) TrySets(constructors Constructors) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.TrySets(constructors)
+	})
+
+	return err
+}
+
+// AddWithSchema adds an object constructor with a given unique id and
+// argument schema to factory.
+func (g *Global) AddWithSchema(name string, constructor Constructor, schema Schema) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.AddWithSchema(name, constructor, schema)
+	})
+
+	return err
+}
+
+// SetWithSchema sets an object constructor with a given unique id and
+// argument schema to factory.
+func (g *Global) SetWithSchema(name string, constructor Constructor, schema Schema) {
+	g.guard.Write(func() {
+		g.instance.SetWithSchema(name, constructor, schema)
+	})
+}
+
+// SchemaOf returns argument schema of registered object constructor.
+func (g *Global) SchemaOf(name string) (schema Schema, err error) {
+	g.guard.Read(func() {
+		schema, err = g.instance.SchemaOf(name)
+	})
+
+	return schema, err
+}
+
+// AddFunc adds an object constructor calling a given function with a given
+// unique id to factory. See Func for supported functions.
+func (g *Global) AddFunc(name string, function interface{}) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.AddFunc(name, function)
+	})
+
+	return err
+}
+
+// SetFunc sets an object constructor calling a given function with a given
+// unique id to factory. See Func for supported functions.
+func (g *Global) SetFunc(name string, function interface{}) {
+	g.guard.Write(func() {
+		g.instance.SetFunc(name, function)
+	})
+}
+
+// SetWithLifetime sets an object constructor with a given unique id and
+// object lifetime to factory.
+func (g *Global) SetWithLifetime(name string, constructor Constructor, lifetime Lifetime) {
+	g.guard.Write(func() {
+		g.instance.SetWithLifetime(name, constructor, lifetime)
+	})
+}
+
+// LifetimeOf returns object lifetime of registered object constructor.
+func (g *Global) LifetimeOf(name string) (lifetime Lifetime, err error) {
+	g.guard.Read(func() {
+		lifetime, err = g.instance.LifetimeOf(name)
+	})
+
+	return lifetime, err
+}
+
+// NewScope creates a new scope of objects created by factory.
+func (g *Global) NewScope() *Scope {
+	return newScope(func(function func(f *Factory)) {
+		g.guard.Read(func() {
+			function(g.instance)
+		})
+	})
+}
+
+// Get returns registered constructor by given name.
+func (g *Global) Get(name string) (constructor Constructor, err error) {
+	g.guard.Read(func() {
+		constructor, err = g.instance.Get(name)
+	})
+
+	return constructor, err
+}
+
+// Gets returns registered constructors by given names.
+func (g *Global) Gets(names []string) (constructors Constructors, err error) {
+	g.guard.Read(func() {
+		constructors, err = g.instance.Gets(names)
+	})
+
+	return constructors, err
+}
+
+// GetAll returns all registered constructors.
+func (g *Global) GetAll() (constructors Constructors) {
+	g.guard.Read(func() {
+		constructors = g.instance.GetAll()
+	})
+
+	return constructors
+}
+
+// Entries returns all registered constructors with their registration details sorted by name.
+func (g *Global) Entries() (entries []registry.Entry) {
+	g.guard.Read(func() {
+		entries = g.instance.Entries()
+	})
+
+	return entries
+}
+
+// Remove removes registered constructor.
+func (g *Global) Remove(name string) {
+	g.guard.Write(func() {
+		g.instance.Remove(name)
+	})
+}
+
+// Removes removes registered constructor.
+func (g *Global) Removes(names []string) {
+	g.guard.Write(func() {
+		g.instance.Removes(names)
+	})
+}
+
+// RemoveAll removes all registered constructor.
+func (g *Global) RemoveAll() {
+	g.guard.Write(func() {
+		g.instance.RemoveAll()
+	})
+}
+
+// IsExist returns true if constructor with given name was registered, otherwise it returns false.
+func (g *Global) IsExist(name string) (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsExist(name)
+	})
+
+	return value
+}
+
+// IsExists returns true if all object constructors with given names were registered, otherwise it returns false.
+func (g *Global) IsExists(names []string) (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsExists(names)
+	})
+
+	return value
+}
+
+// IsEmpty returns true if there are no registered object constructors, otherwise it returns false.
+func (g *Global) IsEmpty() (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsEmpty()
+	})
+
+	return value
+}
+
+// Size returns number of registered object constructors.
+func (g *Global) Size() (value int) {
+	g.guard.Read(func() {
+		value = g.instance.Size()
+	})
+
+	return value
+}
+
+// Replace replaces global factory instance with a given one and returns
+// the previous instance. A nil instance is replaced with a new empty factory.
+func (g *Global) Replace(instance *Factory) (previous *Factory) {
+	if instance == nil {
+		instance = New()
+	}
+
+	g.guard.Write(func() {
+		previous = g.instance
+		g.instance = instance
+	})
+
+	return previous
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestGlobalNamed(test *testing.T) {
+	drivers := registry.Named("drivers")
+	codecs := factory.Named("codecs")
+
+	defer drivers.RemoveAll()
+	defer codecs.RemoveAll()
+
+	assert.Same(test, codecs, factory.Named("codecs"))
+	assert.Equal(test, "codecs", codecs.Name())
+
+	codecs.Set("default", Constructor)
+
+	object, err := codecs.Create("default")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, object)
+
+	_, err = factory.Create("default")
+	assert.Error(test, err)
+	assert.False(test, drivers.IsExist("default"))
+}
+
+func TestGlobalDefault(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", Constructor)
+
+	global := factory.Named(factory.DefaultGlobal)
+
+	assert.True(test, global.IsExist("constructor"))
+	assert.Equal(test, 1, global.Size())
+}
+
+func TestGlobalGlobals(test *testing.T) {
+	factory.Named("globals-b")
+	factory.Named("globals-a")
+
+	names := factory.Globals()
+
+	assert.Subset(test, names, []string{"globals-a", "globals-b"})
+	assert.True(test, sort.StringsAreSorted(names))
+}
+
+func TestGlobalMethods(test *testing.T) {
+	global := factory.Named("methods")
+
+	defer global.RemoveAll()
+
+	global.Configure(registry.WithNormalizer(registry.FoldCase))
+
+	assert.NoError(test, global.Add("ConstructorA", Constructor))
+	assert.Error(test, global.Add("constructora", Constructor))
+	assert.NoError(test, global.Adds(factory.Constructors{"constructorB": Constructor}))
+
+	global.Sets(factory.Constructors{"constructorC": ConstructorError})
+
+	objects, err := global.Creates([]string{"constructora", "constructorb"})
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, 2)
+
+	_, err = global.Create("constructorc")
+	assert.Error(test, err)
+
+	constructors, err := global.Gets([]string{"constructora", "constructorb"})
+
+	assert.NoError(test, err)
+	assert.Len(test, constructors, 2)
+	assert.Len(test, global.GetAll(), 3)
+	assert.Len(test, global.Entries(), 3)
+	assert.True(test, global.IsExists([]string{"CONSTRUCTORA", "CONSTRUCTORC"}))
+
+	global.Removes([]string{"constructora", "constructorb"})
+	assert.Equal(test, 1, global.Size())
+
+	previous := global.Replace(nil)
+	assert.True(test, global.IsEmpty())
+	assert.Equal(test, 1, previous.Size())
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"sync"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Lifetime defines how long an object created by a registered constructor lives.
+type Lifetime int
+
+// Object lifetimes.
+const (
+	// Transient objects are created every time they are requested.
+	Transient Lifetime = iota
+
+	// Singleton objects are created once, on first request, and shared.
+	Singleton
+
+	// Scoped objects are created once per scope and closed with the scope.
+	Scoped
+)
+
+// registration defines an object constructor registered with a lifetime
+// other than transient, with an argument schema or a context-aware object
+// constructor. It holds the shared object of a singleton and the original
+// function wrapped by the object constructor, if any.
+type registration struct {
+	constructor        Constructor
+	contextConstructor ContextConstructor
+	function           interface{}
+	lifetime           Lifetime
+	schema             Schema
+	mutex              sync.Mutex
+	object             interface{}
+	pending            *pending
+}
+
+// pending defines a creation of a shared object in progress. Requests for the
+// same object made meanwhile wait for its result instead of creating it again.
+type pending struct {
+	done   chan struct{}
+	object interface{}
+	err    error
+}
+
+// String returns lifetime name.
+func (l Lifetime) String() string {
+	switch l {
+	case Transient:
+		return "transient"
+	case Singleton:
+		return "singleton"
+	case Scoped:
+		return "scoped"
+	default:
+		return "unknown"
+	}
+}
+
+// AddWithLifetime adds an object constructor with a given unique id and
+// object lifetime to registry.
+func (f *Factory) AddWithLifetime(name string, constructor Constructor, lifetime Lifetime) error {
+	object, err := newRegistration(constructor, lifetime)
+
+	if err != nil {
+		return err
+	}
+
+	return f.registry.Add(name, object)
+}
+
+// SetWithLifetime sets an object constructor with a given unique id and
+// object lifetime to registry.
+func (f *Factory) SetWithLifetime(name string, constructor Constructor, lifetime Lifetime) *Factory {
+	object, err := newRegistration(constructor, lifetime)
+
+	if err != nil {
+		panic(err)
+	}
+
+	f.registry.Set(name, object)
+
+	return f
+}
+
+// LifetimeOf returns object lifetime of registered object constructor.
+func (f *Factory) LifetimeOf(name string) (Lifetime, error) {
+	object, err := f.registry.Get(name)
+
+	if err != nil {
+		return Transient, err
+	}
+
+	return lifetimeOf(object), nil
+}
+
+// singleton returns the shared object created by a singleton constructor.
+// The object is created on first successful call.
+func (r *registration) singleton(ctx context.Context, f *Factory, name string,
+	arguments ...interface{}) (object interface{}, err error) {
+	r.mutex.Lock()
+
+	if r.object != nil {
+		r.mutex.Unlock()
+		return r.object, nil
+	}
+
+	if p := r.pending; p != nil {
+		r.mutex.Unlock()
+		return p.wait(ctx, name)
+	}
+
+	p := newPending()
+	r.pending = p
+	r.mutex.Unlock()
+
+	defer func() {
+		r.mutex.Lock()
+
+		if err == nil {
+			r.object = object
+		}
+
+		r.pending = nil
+		r.mutex.Unlock()
+
+		p.finish(object, err)
+	}()
+
+	// Object is created without holding the lock, so its constructor can
+	// create other objects. Waiting requests get an error if it panics.
+	err = rterror.New("object constructor panicked", name)
+
+	return f.construct(ctx, name, contextConstructorOf(r), arguments...)
+}
+
+// clone returns a copy of registration without a shared object and a creation
+// in progress.
+func (r *registration) clone() *registration {
+	return &registration{
+		constructor:        r.constructor,
+		contextConstructor: r.contextConstructor,
+		function:           r.function,
+		lifetime:           r.lifetime,
+		schema:             r.schema,
+	}
+}
+
+// newPending creates a new creation of a shared object in progress.
+func newPending() *pending {
+	return &pending{
+		done: make(chan struct{}),
+	}
+}
+
+// wait waits for a result of a given object creation in progress.
+func (p *pending) wait(ctx context.Context, name string) (interface{}, error) {
+	select {
+	case <-p.done:
+		return p.object, p.err
+	case <-ctx.Done():
+		return nil, rterror.New("cannot create object", name, ctx.Err())
+	}
+}
+
+// finish sets a result of object creation and wakes up all waiting requests.
+func (p *pending) finish(object interface{}, err error) {
+	p.object, p.err = object, err
+	close(p.done)
+}
+
+// newRegistration returns a registry object for a given constructor and lifetime.
+func newRegistration(constructor Constructor, lifetime Lifetime) (interface{}, error) {
+	switch lifetime {
+	case Transient:
+		return constructor, nil
+	case Singleton, Scoped:
+		return &registration{
+			constructor: constructor,
+			lifetime:    lifetime,
+		}, nil
+	default:
+		return nil, rterror.New("invalid object lifetime", int(lifetime))
+	}
+}
+
+// lifetimeOf returns object lifetime of a given registry object.
+func lifetimeOf(object interface{}) Lifetime {
+	if r, ok := object.(*registration); ok {
+		return r.lifetime
+	}
+
+	return Transient
+}
@@ -0,0 +1,292 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type service struct {
+	id int64
+}
+
+func counter(count *int64) factory.Constructor {
+	return func(...interface{}) (interface{}, error) {
+		return &service{id: atomic.AddInt64(count, 1)}, nil
+	}
+}
+
+func TestLifetimeString(test *testing.T) {
+	assert.Equal(test, "transient", factory.Transient.String())
+	assert.Equal(test, "singleton", factory.Singleton.String())
+	assert.Equal(test, "scoped", factory.Scoped.String())
+	assert.Equal(test, "unknown", factory.Lifetime(-1).String())
+}
+
+func TestLifetimeTransient(test *testing.T) {
+	var count int64
+
+	f := factory.New()
+
+	assert.NoError(test, f.AddWithLifetime("service", counter(&count), factory.Transient))
+
+	first, err := f.Create("service")
+	assert.NoError(test, err)
+
+	second, err := f.Create("service")
+	assert.NoError(test, err)
+
+	assert.NotSame(test, first, second)
+	assert.Equal(test, int64(2), count)
+
+	lifetime, err := f.LifetimeOf("service")
+
+	assert.NoError(test, err)
+	assert.Equal(test, factory.Transient, lifetime)
+}
+
+func TestLifetimeSingleton(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("service", counter(&count), factory.Singleton)
+
+	objects := make([]interface{}, 16)
+
+	var wait sync.WaitGroup
+
+	for i := range objects {
+		wait.Add(1)
+
+		go func(index int) {
+			defer wait.Done()
+
+			objects[index], _ = f.Create("service")
+		}(i)
+	}
+
+	wait.Wait()
+
+	for _, object := range objects {
+		assert.Same(test, objects[0], object)
+	}
+
+	assert.Equal(test, int64(1), count)
+
+	lifetime, err := f.LifetimeOf("service")
+
+	assert.NoError(test, err)
+	assert.Equal(test, factory.Singleton, lifetime)
+
+	constructor, err := f.Get("service")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, constructor)
+	assert.Len(test, f.GetAll(), 1)
+	assert.IsType(test, factory.Constructor(nil), f.Entries()[0].Object)
+}
+
+func TestLifetimeSingletonNested(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("db", counter(&count), factory.Singleton)
+
+	f.SetWithLifetime("repository", func(...interface{}) (interface{}, error) {
+		return f.Create("db")
+	}, factory.Singleton)
+
+	repository, err := f.Create("repository")
+	assert.NoError(test, err)
+
+	db, err := f.Create("db")
+	assert.NoError(test, err)
+
+	assert.Same(test, db, repository)
+	assert.Equal(test, int64(1), count)
+}
+
+func TestLifetimeSingletonWaitCancelled(test *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	f := factory.New().SetWithLifetime("service", func(...interface{}) (interface{}, error) {
+		close(started)
+		<-release
+
+		return &service{}, nil
+	}, factory.Singleton)
+
+	done := make(chan interface{})
+
+	go func() {
+		object, _ := f.Create("service")
+		done <- object
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	object, err := f.CreateContext(ctx, "service")
+
+	assert.Error(test, err)
+	assert.Nil(test, object)
+
+	close(release)
+
+	object = <-done
+
+	assert.NotNil(test, object)
+
+	created, err := f.Create("service")
+
+	assert.NoError(test, err)
+	assert.Same(test, object, created)
+}
+
+func TestLifetimeSingletonPanic(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("service", func(arguments ...interface{}) (interface{}, error) {
+		if atomic.AddInt64(&count, 1) == 1 {
+			panic("failure")
+		}
+
+		return &service{id: count}, nil
+	}, factory.Singleton)
+
+	assert.Panics(test, func() { _, _ = f.Create("service") })
+
+	object, err := f.Create("service")
+
+	assert.NoError(test, err)
+	assert.Equal(test, &service{id: 2}, object)
+}
+
+func TestLifetimeSingletonError(test *testing.T) {
+	var count int64
+
+	failed := false
+
+	f := factory.New().SetWithLifetime("service", func(arguments ...interface{}) (interface{}, error) {
+		if !failed {
+			failed = true
+			return ConstructorError(arguments...)
+		}
+
+		return counter(&count)(arguments...)
+	}, factory.Singleton)
+
+	_, err := f.Create("service")
+	assert.Error(test, err)
+
+	first, err := f.Create("service")
+	assert.NoError(test, err)
+
+	second, err := f.Create("service")
+	assert.NoError(test, err)
+
+	assert.Same(test, first, second)
+	assert.Equal(test, int64(1), count)
+}
+
+func TestLifetimeSingletonReplaced(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("service", counter(&count), factory.Singleton)
+
+	first, err := f.Create("service")
+	assert.NoError(test, err)
+
+	f.SetWithLifetime("service", counter(&count), factory.Singleton)
+
+	second, err := f.Create("service")
+	assert.NoError(test, err)
+
+	assert.NotSame(test, first, second)
+}
+
+func TestLifetimeScopedOutsideScope(test *testing.T) {
+	f := factory.New().SetWithLifetime("service", Constructor, factory.Scoped)
+
+	_, err := f.Create("service")
+	assert.Error(test, err)
+}
+
+func TestLifetimeInvalid(test *testing.T) {
+	f := factory.New()
+
+	assert.Error(test, f.AddWithLifetime("service", Constructor, factory.Lifetime(10)))
+	assert.False(test, f.IsExist("service"))
+
+	assert.Panics(test, func() {
+		f.SetWithLifetime("service", Constructor, factory.Lifetime(10))
+	})
+
+	_, err := f.LifetimeOf("service")
+	assert.Error(test, err)
+}
+
+func TestLifetimeDiff(test *testing.T) {
+	a := factory.New().SetWithLifetime("service", Constructor, factory.Singleton)
+	b := factory.New().SetWithLifetime("service", Constructor, factory.Singleton)
+
+	assert.True(test, factory.Diff(a, b, nil).IsEmpty())
+
+	b.SetWithLifetime("service", Constructor, factory.Scoped)
+
+	assert.Equal(test, registry.Names{"service"}, factory.Diff(a, b, nil).Changed)
+}
+
+func TestLifetimeGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	var count int64
+
+	assert.NoError(test, factory.AddWithLifetime("singleton", counter(&count), factory.Singleton))
+	factory.SetWithLifetime("scoped", counter(&count), factory.Scoped)
+
+	lifetime, err := factory.LifetimeOf("scoped")
+
+	assert.NoError(test, err)
+	assert.Equal(test, factory.Scoped, lifetime)
+
+	first, err := factory.Create("singleton")
+	assert.NoError(test, err)
+
+	second, err := factory.Create("singleton")
+	assert.NoError(test, err)
+
+	assert.Same(test, first, second)
+
+	scope := factory.NewScope()
+	defer scope.Close()
+
+	first, err = scope.Create("scoped")
+	assert.NoError(test, err)
+
+	second, err = scope.Create("scoped")
+	assert.NoError(test, err)
+
+	assert.Same(test, first, second)
+}
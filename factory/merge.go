@@ -0,0 +1,82 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"reflect"
+
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// Diff returns names of object constructors added, removed and changed in
+// factory b compared to factory a. Object constructors are compared with
+// a given equal function or by their function pointers if it is nil.
+func Diff(a, b *Factory, equal registry.EqualFunc) registry.Difference {
+	if equal == nil {
+		equal = isSameFunction
+	}
+
+	return registry.Diff(&a.registry, &b.registry, equal)
+}
+
+// Merge merges object constructors from source factory to destination factory
+// using a given merge strategy. Singleton and scoped object constructors are
+// merged without objects already created by source factory.
+func Merge(dst, src *Factory, strategy registry.Strategy) error {
+	objects := src.registry.GetAll()
+
+	for name, object := range objects {
+		if r, ok := object.(*registration); ok {
+			objects[name] = r.clone()
+		}
+	}
+
+	return registry.Merge(&dst.registry, registry.New().Sets(objects), strategy)
+}
+
+// isSameFunction returns true if given objects are the same functions
+// registered with the same object lifetime and argument schema.
+func isSameFunction(a, b interface{}) bool {
+	if (lifetimeOf(a) != lifetimeOf(b)) || !reflect.DeepEqual(schemaOf(a), schemaOf(b)) {
+		return false
+	}
+
+	a, b = functionOf(a), functionOf(b)
+
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+
+	if (x.Kind() != reflect.Func) || (y.Kind() != reflect.Func) {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return (x.Type() == y.Type()) && (x.Pointer() == y.Pointer())
+}
+
+// functionOf returns a function registered for a given registry object.
+func functionOf(object interface{}) interface{} {
+	if r, ok := object.(*registration); ok {
+		if r.function != nil {
+			return r.function
+		}
+
+		return r.constructor
+	}
+
+	return object
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestDiff(test *testing.T) {
+	a := factory.New().Sets(factory.Constructors{
+		"constructorA": Constructor,
+		"constructorB": Constructor,
+		"constructorC": nil,
+	})
+
+	b := factory.New().Sets(factory.Constructors{
+		"constructorB": ConstructorError,
+		"constructorC": nil,
+		"constructorD": Constructor,
+	})
+
+	difference := factory.Diff(a, b, nil)
+
+	assert.Equal(test, registry.Names{"constructorD"}, difference.Added)
+	assert.Equal(test, registry.Names{"constructorA"}, difference.Removed)
+	assert.Equal(test, registry.Names{"constructorB"}, difference.Changed)
+	assert.True(test, factory.Diff(a, a, nil).IsEmpty())
+
+	difference = factory.Diff(a, b, func(x, y interface{}) bool {
+		return true
+	})
+
+	assert.Empty(test, difference.Changed)
+}
+
+func TestMerge(test *testing.T) {
+	dst := factory.New().Set("constructorA", Constructor)
+	src := factory.New().Sets(factory.Constructors{
+		"constructorA": ConstructorError,
+		"constructorB": Constructor,
+	})
+
+	assert.Error(test, factory.Merge(dst, src, registry.ErrorOnConflict))
+	assert.NoError(test, factory.Merge(dst, src, registry.KeepExisting))
+
+	object, err := dst.Create("constructorA")
+
+	assert.NoError(test, err)
+	assert.NotNil(test, object)
+
+	assert.NoError(test, factory.Merge(dst, src, registry.Overwrite))
+
+	_, err = dst.Create("constructorA")
+
+	assert.Error(test, err)
+	assert.Equal(test, 2, dst.Size())
+}
+
+func TestMergeLifetimes(test *testing.T) {
+	var count int64
+
+	src := factory.New().
+		SetWithLifetime("singleton", counter(&count), factory.Singleton).
+		SetWithLifetime("scoped", counter(&count), factory.Scoped)
+
+	created, err := src.Create("singleton")
+	assert.NoError(test, err)
+
+	dst := factory.New()
+
+	assert.NoError(test, factory.Merge(dst, src, registry.Overwrite))
+	assert.True(test, factory.Diff(src, dst, nil).IsEmpty())
+
+	first, err := dst.Create("singleton")
+	assert.NoError(test, err)
+
+	second, err := dst.Create("singleton")
+	assert.NoError(test, err)
+
+	assert.NotSame(test, created, first)
+	assert.Same(test, first, second)
+
+	lifetime, err := dst.LifetimeOf("scoped")
+
+	assert.NoError(test, err)
+	assert.Equal(test, factory.Scoped, lifetime)
+}
+
+func TestDiffFunctions(test *testing.T) {
+	a := factory.New().SetFunc("server", newServer).SetFunc("tagged", newTagged)
+	b := factory.New().SetFunc("server", newServer).SetFunc("tagged", newServer)
+
+	assert.Equal(test, registry.Names{"tagged"}, factory.Diff(a, b, nil).Changed)
+	assert.True(test, factory.Diff(a, a, nil).IsEmpty())
+}
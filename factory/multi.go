@@ -0,0 +1,119 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// Multi defines a multi factory that can register many object constructors
+// under the same name, for example contributions to an extension point.
+type Multi struct {
+	factory *Factory
+	multi   *registry.Multi
+}
+
+// NewMulti creates a new multi factory instance. Given registry options are
+// applied to names of registered object constructors.
+func NewMulti(options ...registry.Option) *Multi {
+	return &Multi{
+		factory: New(options...),
+		multi:   registry.NewMulti(options...),
+	}
+}
+
+// Add adds an object constructor under a given name with the default zero
+// priority. It returns handle that can be used to remove that constructor.
+func (m *Multi) Add(name string, constructor Constructor) (registry.Handle, error) {
+	return m.multi.Add(name, constructor)
+}
+
+// AddWithPriority adds an object constructor under a given name with a given
+// priority. It returns handle that can be used to remove that constructor.
+func (m *Multi) AddWithPriority(name string, constructor Constructor, priority int) (registry.Handle, error) {
+	return m.multi.AddWithPriority(name, constructor, priority)
+}
+
+// CreateAll creates objects with all object constructors registered under
+// a given name in priority order. It returns successfully created objects
+// and an error listing all failures.
+func (m *Multi) CreateAll(name string, arguments ...interface{}) ([]interface{}, error) {
+	constructors := m.GetAll(name)
+
+	objects := make([]interface{}, 0, len(constructors))
+	errs := make([]interface{}, 0, len(constructors))
+
+	for _, constructor := range constructors {
+		start := time.Now()
+		object, err := m.factory.construct(context.Background(), name, withContext(constructor), arguments...)
+		m.factory.record(name, start, err)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	if len(errs) != 0 {
+		return objects, rterror.New("cannot create objects", errs...)
+	}
+
+	return objects, nil
+}
+
+// GetAll returns all object constructors registered under a given name in priority order.
+func (m *Multi) GetAll(name string) []Constructor {
+	objects := m.multi.GetAll(name)
+	constructors := make([]Constructor, 0, len(objects))
+
+	for _, object := range objects {
+		constructors = append(constructors, toConstructor(object))
+	}
+
+	return constructors
+}
+
+// Names returns sorted names with registered object constructors.
+func (m *Multi) Names() registry.Names {
+	return m.multi.Names()
+}
+
+// Remove removes object constructor registered with a given handle.
+func (m *Multi) Remove(handle registry.Handle) *Multi {
+	m.multi.Remove(handle)
+	return m
+}
+
+// RemoveAll removes all object constructors registered under a given name.
+func (m *Multi) RemoveAll(name string) *Multi {
+	m.multi.RemoveAll(name)
+	return m
+}
+
+// IsExist returns true if any object constructor was registered under a given name, otherwise it returns false.
+func (m *Multi) IsExist(name string) bool {
+	return m.multi.IsExist(name)
+}
+
+// Size returns number of all registered object constructors.
+func (m *Multi) Size() int {
+	return m.multi.Size()
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+func TestMultiCreateAll(test *testing.T) {
+	m := factory.NewMulti()
+
+	_, err := m.AddWithPriority("http.middleware", func(...interface{}) (interface{}, error) {
+		return "low", nil
+	}, -1)
+
+	assert.NoError(test, err)
+
+	_, err = m.AddWithPriority("http.middleware", func(...interface{}) (interface{}, error) {
+		return "high", nil
+	}, 1)
+
+	assert.NoError(test, err)
+
+	objects, err := m.CreateAll("http.middleware")
+
+	assert.NoError(test, err)
+	assert.Equal(test, []interface{}{"high", "low"}, objects)
+	assert.Len(test, m.GetAll("http.middleware"), 2)
+	assert.Equal(test, 2, m.Size())
+}
+
+func TestMultiCreateAllError(test *testing.T) {
+	m := factory.NewMulti()
+
+	_, err := m.Add("shutdown.hook", Constructor)
+	assert.NoError(test, err)
+
+	_, err = m.Add("shutdown.hook", ConstructorError)
+	assert.NoError(test, err)
+
+	_, err = m.Add("shutdown.hook", ConstructorNil)
+	assert.NoError(test, err)
+
+	objects, err := m.CreateAll("shutdown.hook")
+
+	assert.Error(test, err)
+	assert.Len(test, objects, 1)
+
+	objects, err = m.CreateAll("unknown")
+
+	assert.NoError(test, err)
+	assert.Empty(test, objects)
+}
+
+func TestMultiRemove(test *testing.T) {
+	m := factory.NewMulti()
+
+	handle, err := m.Add("shutdown.hook", Constructor)
+
+	assert.NoError(test, err)
+
+	_, err = m.Add("http.middleware", Constructor)
+
+	assert.NoError(test, err)
+	assert.True(test, m.IsExist("shutdown.hook"))
+	assert.Same(test, m, m.Remove(handle))
+	assert.False(test, m.IsExist("shutdown.hook"))
+	assert.Equal(test, []string{"http.middleware"}, []string(m.Names()))
+	assert.Same(test, m, m.RemoveAll("http.middleware"))
+	assert.Zero(test, m.Size())
+}
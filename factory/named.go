@@ -0,0 +1,228 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// OptionTag defines a struct field tag with option name used by CreateNamed.
+const OptionTag = "factory"
+
+// Options defines named options passed to object constructor.
+type Options map[string]interface{}
+
+// durationType defines the reflection type of time duration.
+var durationType = reflect.TypeOf(time.Duration(0)) // nolint: gochecknoglobals
+
+// CreateNamed creates a new object based on given name with named options.
+// Options can be a map with string keys or a struct. Struct fields are named
+// by the OptionTag tag or by field names. Options are matched with argument
+// names of object constructor schema, exactly or ignoring case, and passed to
+// object constructor as positional arguments. Omitted optional arguments get
+// their default values. String options are parsed to numeric, boolean and
+// duration argument types, so options can be read directly from
+// configuration files.
+func (f *Factory) CreateNamed(name string, options interface{}) (interface{}, error) {
+	schema, err := f.SchemaOf(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if schema == nil {
+		return nil, rterror.New("object constructor was registered without argument schema", name)
+	}
+
+	named, err := toOptions(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := schema.arguments(name, named)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Create(name, arguments...)
+}
+
+// arguments returns positional arguments for given named options.
+func (s Schema) arguments(name string, options Options) ([]interface{}, error) {
+	arguments := make([]interface{}, len(s))
+	used := make(map[string]bool, len(options))
+
+	for position := range s {
+		argument := &s[position]
+		key, ok := argument.find(options)
+
+		if !ok {
+			if !argument.Optional {
+				return nil, invalidArguments(&InvalidArgumentsError{
+					Name:     name,
+					Position: position,
+					Argument: argument.Name,
+					Reason:   "required option is missing",
+				})
+			}
+
+			arguments[position] = argument.defaultValue()
+
+			continue
+		}
+
+		used[key] = true
+
+		value, err := coerce(options[key], argument.Type)
+
+		if err != nil {
+			return nil, invalidArguments(&InvalidArgumentsError{
+				Name:     name,
+				Position: position,
+				Argument: argument.Name,
+				Reason:   "cannot parse option: " + err.Error(),
+			})
+		}
+
+		arguments[position] = value
+	}
+
+	for key := range options {
+		if !used[key] {
+			return nil, invalidArguments(&InvalidArgumentsError{
+				Name:     name,
+				Position: -1,
+				Argument: key,
+				Reason:   "unknown option",
+			})
+		}
+	}
+
+	return arguments, nil
+}
+
+// find returns key of option matching argument name, exactly or ignoring case.
+func (a *Argument) find(options Options) (string, bool) {
+	if a.Name == "" {
+		return "", false
+	}
+
+	if _, ok := options[a.Name]; ok {
+		return a.Name, true
+	}
+
+	for key := range options {
+		if strings.EqualFold(key, a.Name) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// toOptions returns named options from a given map or struct.
+func toOptions(options interface{}) (Options, error) {
+	switch value := options.(type) {
+	case nil:
+		return Options{}, nil
+	case Options:
+		return value, nil
+	case map[string]interface{}:
+		return value, nil
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(options))
+	named := Options{}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, rterror.New("options map must have string keys", value.Type().String())
+		}
+
+		iterator := value.MapRange()
+
+		for iterator.Next() {
+			named[iterator.Key().String()] = iterator.Value().Interface()
+		}
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			field := value.Type().Field(index)
+
+			if field.PkgPath != "" {
+				continue
+			}
+
+			key := field.Name
+
+			if tag, ok := field.Tag.Lookup(OptionTag); ok {
+				if tag == "-" {
+					continue
+				}
+
+				key = tag
+			}
+
+			named[key] = value.Field(index).Interface()
+		}
+	default:
+		return nil, rterror.New("options must be a map or a struct", typeString(options))
+	}
+
+	return named, nil
+}
+
+// coerce parses a given string value to numeric, boolean or duration type.
+// Other values are returned unchanged.
+func coerce(value interface{}, kind reflect.Type) (interface{}, error) {
+	text, ok := value.(string)
+
+	if !ok || (kind == nil) {
+		return value, nil
+	}
+
+	if kind == durationType {
+		return time.ParseDuration(text)
+	}
+
+	var parsed interface{}
+	var err error
+
+	switch kind.Kind() {
+	case reflect.Bool:
+		parsed, err = strconv.ParseBool(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err = strconv.ParseInt(text, 0, kind.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err = strconv.ParseUint(text, 0, kind.Bits())
+	case reflect.Float32, reflect.Float64:
+		parsed, err = strconv.ParseFloat(text, kind.Bits())
+	default:
+		return value, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.ValueOf(parsed).Convert(kind).Interface(), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+type ServerOptions struct {
+	Address string
+	Port    int           `factory:"port"`
+	Timeout time.Duration `factory:"timeout"`
+	Ignored string        `factory:"-"`
+	hidden  string
+}
+
+func TestNamedCreateMap(test *testing.T) {
+	f := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	object, err := f.CreateNamed("server", factory.Options{
+		"address": "localhost",
+		"timeout": "1m30s",
+	})
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 80, timeout: 90 * time.Second}, object)
+
+	object, err = f.CreateNamed("server", map[string]interface{}{
+		"Address": "localhost",
+		"port":    "0x1F90",
+	})
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080}, object)
+
+	object, err = f.CreateNamed("server", map[string]string{
+		"address": "localhost",
+		"port":    "8080",
+		"timeout": "1s",
+	})
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080, timeout: time.Second}, object)
+}
+
+func TestNamedCreateJSON(test *testing.T) {
+	f := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	options := map[string]interface{}{}
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"address":"localhost","port":8080,"timeout":"2s"}`), &options))
+
+	object, err := f.CreateNamed("server", options)
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080, timeout: 2 * time.Second}, object)
+}
+
+func TestNamedCreateStruct(test *testing.T) {
+	f := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	object, err := f.CreateNamed("server", &ServerOptions{
+		Address: "localhost",
+		Port:    8080,
+		Ignored: "ignored",
+		hidden:  "hidden",
+	})
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080}, object)
+}
+
+func TestNamedCreateBool(test *testing.T) {
+	f := factory.New().SetWithSchema("flag", func(arguments ...interface{}) (interface{}, error) {
+		return arguments[0], nil
+	}, factory.Schema{
+		{Name: "enabled", Type: reflect.TypeOf(false)},
+	})
+
+	object, err := f.CreateNamed("flag", factory.Options{"enabled": "true"})
+
+	assert.NoError(test, err)
+	assert.Equal(test, true, object)
+
+	_, err = f.CreateNamed("flag", factory.Options{"enabled": "yes"})
+	assert.Error(test, err)
+}
+
+func TestNamedCreateErrors(test *testing.T) {
+	f := factory.New().
+		SetWithSchema("server", ServerConstructor, ServerSchema).
+		Set("constructor", Constructor)
+
+	var invalid *factory.InvalidArgumentsError
+
+	_, err := f.CreateNamed("server", factory.Options{"port": 80})
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, "address", invalid.Argument)
+	assert.Equal(test, 0, invalid.Position)
+
+	_, err = f.CreateNamed("server", factory.Options{"address": "localhost", "timeot": "1s"})
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, "timeot", invalid.Argument)
+	assert.Equal(test, -1, invalid.Position)
+	assert.Contains(test, err.Error(), "invalid argument (timeot) of server: unknown option")
+
+	_, err = f.CreateNamed("server", factory.Options{"address": "localhost", "port": "http"})
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, 1, invalid.Position)
+
+	_, err = f.CreateNamed("server", factory.Options{"address": "localhost", "port": true})
+
+	assert.True(test, errors.As(err, &invalid))
+	assert.Equal(test, 1, invalid.Position)
+
+	_, err = f.CreateNamed("server", 1)
+	assert.Error(test, err)
+
+	_, err = f.CreateNamed("server", map[int]string{})
+	assert.Error(test, err)
+
+	_, err = f.CreateNamed("constructor", nil)
+	assert.Error(test, err)
+
+	_, err = f.CreateNamed("unknown", nil)
+	assert.Error(test, err)
+}
+
+func TestNamedCreateGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	object, err := factory.CreateNamed("server", factory.Options{"address": "localhost"})
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 80}, object)
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Parallel defines options of parallel object creation.
+type Parallel struct {
+	// Workers is the maximum number of objects created concurrently. It is
+	// the number of usable CPUs if it is not positive.
+	Workers int
+
+	// FailFast cancels creation of remaining objects on the first error.
+	FailFast bool
+}
+
+// CreatesParallel creates a list of new objects based on given names
+// concurrently by a bounded pool of workers. Created objects are returned at
+// positions of their names, with nil at positions of objects that were not
+// created. Returned error aggregates errors of all objects that were not
+// created, in order of their names. When the context is done or on the first
+// error with FailFast, remaining objects are not created. If an object
+// constructor panics, remaining objects are not created and CreatesParallel
+// panics with the same value after all workers stop.
+func (f *Factory) CreatesParallel(ctx context.Context, names []string, parallel Parallel,
+	arguments ...interface{}) ([]interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make([]interface{}, len(names))
+	errs := make([]error, len(names))
+	indexes := make(chan int)
+
+	var (
+		wait   sync.WaitGroup
+		once   sync.Once
+		failed *result
+	)
+
+	for worker := 0; worker < parallel.workers(len(names)); worker++ {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			for index := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[index] = rterror.New("object was not created", names[index], err)
+					continue
+				}
+
+				r := f.createRecovered(ctx, names[index], arguments)
+
+				if r.panicked {
+					once.Do(func() { failed = &r })
+					cancel()
+
+					continue
+				}
+
+				objects[index], errs[index] = r.object, r.err
+
+				if (errs[index] != nil) && parallel.FailFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for index := range names {
+		indexes <- index
+	}
+
+	close(indexes)
+	wait.Wait()
+
+	if failed != nil {
+		panic(failed.panic)
+	}
+
+	failures := make([]interface{}, 0, len(names))
+
+	for _, err := range errs {
+		if err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	if len(failures) != 0 {
+		return objects, rterror.New("cannot create objects", failures...)
+	}
+
+	return objects, nil
+}
+
+// createRecovered creates a new object based on given name. It recovers
+// a panic of object constructor and returns it in result.
+func (f *Factory) createRecovered(ctx context.Context, name string, arguments []interface{}) (r result) {
+	r.panicked = true
+
+	defer func() {
+		if r.panicked {
+			r.panic = recover()
+		}
+	}()
+
+	r.object, r.err = f.CreateContext(ctx, name, arguments...)
+	r.panicked = false
+
+	return r
+}
+
+// workers returns the number of workers creating a given number of objects.
+func (p Parallel) workers(count int) int {
+	workers := p.Workers
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > count {
+		workers = count
+	}
+
+	return workers
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+func Sleeper(active, peak *int32) factory.ContextConstructor {
+	return func(ctx context.Context, arguments ...interface{}) (interface{}, error) {
+		current := atomic.AddInt32(active, 1)
+		defer atomic.AddInt32(active, -1)
+
+		for {
+			previous := atomic.LoadInt32(peak)
+
+			if (current <= previous) || atomic.CompareAndSwapInt32(peak, previous, current) {
+				break
+			}
+		}
+
+		select {
+		case <-time.After(arguments[0].(time.Duration)):
+			return arguments[1], nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestCreatesParallel(test *testing.T) {
+	var active, peak int32
+
+	f := factory.New().
+		SetContext("sleeper", Sleeper(&active, &peak)).
+		Set("echo", func(arguments ...interface{}) (interface{}, error) {
+			return arguments[1], nil
+		})
+
+	names := []string{"sleeper", "echo", "sleeper", "echo", "sleeper", "sleeper"}
+
+	objects, err := f.CreatesParallel(context.Background(), names, factory.Parallel{Workers: 2},
+		20*time.Millisecond, "object")
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, len(names))
+	assert.Equal(test, int32(2), atomic.LoadInt32(&peak))
+
+	for _, object := range objects {
+		assert.Equal(test, "object", object)
+	}
+}
+
+func TestCreatesParallelErrors(test *testing.T) {
+	f := factory.New().
+		Set("first", func(...interface{}) (interface{}, error) {
+			return "first", nil
+		}).
+		Set("error", ConstructorError).
+		Set("last", func(...interface{}) (interface{}, error) {
+			return "last", nil
+		})
+
+	objects, err := f.CreatesParallel(context.Background(), []string{"first", "error", "unknown", "last"},
+		factory.Parallel{})
+
+	assert.Error(test, err)
+	assert.Equal(test, []interface{}{"first", nil, nil, "last"}, objects)
+
+	objects, err = f.CreatesParallel(context.Background(), []string{}, factory.Parallel{})
+
+	assert.NoError(test, err)
+	assert.Empty(test, objects)
+}
+
+func TestCreatesParallelFailFast(test *testing.T) {
+	var active, peak int32
+
+	f := factory.New().
+		SetContext("sleeper", Sleeper(&active, &peak)).
+		Set("error", ConstructorError)
+
+	start := time.Now()
+
+	objects, err := f.CreatesParallel(context.Background(),
+		[]string{"error", "sleeper", "sleeper", "sleeper", "sleeper"},
+		factory.Parallel{Workers: 2, FailFast: true}, time.Hour, "object")
+
+	assert.Error(test, err)
+	assert.Less(test, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(test, make([]interface{}, 5), objects)
+}
+
+func TestCreatesParallelCancelled(test *testing.T) {
+	var calls int32
+
+	f := factory.New().Set("constructor", func(...interface{}) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "object", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := f.CreatesParallel(ctx, []string{"constructor", "constructor"}, factory.Parallel{})
+
+	assert.True(test, errors.Is(err, context.Canceled))
+	assert.Equal(test, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestCreatesParallelPanic(test *testing.T) {
+	f := factory.New().
+		Set("constructor", Constructor).
+		Set("panic", func(...interface{}) (interface{}, error) {
+			panic("failure")
+		})
+
+	names := []string{"constructor", "panic", "constructor", "constructor"}
+
+	assert.PanicsWithValue(test, "failure", func() {
+		_, _ = f.CreatesParallel(context.Background(), names, factory.Parallel{Workers: 2})
+	})
+}
+
+func TestCreatesParallelGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", Constructor)
+
+	objects, err := factory.CreatesParallel(context.Background(), []string{"constructor", "constructor"},
+		factory.Parallel{Workers: 1})
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, 2)
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// DiscriminatorField defines the name of the JSON object field holding
+// a name of object constructor used by Polymorphic.
+const DiscriminatorField = "type"
+
+// Creator defines an object creator. It is implemented by Factory, Global
+// and Scope.
+type Creator interface {
+	Create(name string, arguments ...interface{}) (interface{}, error)
+}
+
+// Polymorphic defines a JSON value of an interface type. It is decoded from
+// a JSON object by creating an object with the object constructor named by
+// the DiscriminatorField field and decoding remaining fields into it. It is
+// encoded back to a JSON object with the DiscriminatorField field set to Type.
+//
+// Objects are created by Creator or by the default global factory if it is
+// nil. Decoding into a Polymorphic field of a struct uses a preset Creator.
+type Polymorphic struct {
+	Creator Creator
+	Type    string
+	Value   interface{}
+}
+
+// MarshalJSON encodes value to a JSON object with the discriminator field.
+// A nil value, including a nil pointer or map, is encoded as null.
+func (p Polymorphic) MarshalJSON() ([]byte, error) {
+	if p.Value == nil {
+		return []byte("null"), nil
+	}
+
+	if p.Type == "" {
+		return nil, rterror.New("polymorphic value type cannot be empty", typeString(p.Value))
+	}
+
+	data, err := json.Marshal(p.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+
+	// Nil pointers and maps are encoded as null like a nil value
+	if bytes.Equal(data, []byte("null")) {
+		return data, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+
+	if (len(data) == 0) || (data[0] != '{') {
+		return nil, rterror.New("polymorphic value must be encoded as JSON object", p.Type)
+	}
+
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, rterror.New("polymorphic value must be encoded as JSON object", p.Type, err)
+	}
+
+	if _, ok := fields[DiscriminatorField]; ok {
+		return nil, rterror.New("polymorphic value cannot have discriminator field", p.Type, DiscriminatorField)
+	}
+
+	name, err := json.Marshal(p.Type)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString(`{"` + DiscriminatorField + `":`)
+	buffer.Write(name)
+
+	if rest := bytes.TrimSpace(data[1:]); (len(rest) != 0) && (rest[0] != '}') {
+		buffer.WriteByte(',')
+		buffer.Write(rest)
+	} else {
+		buffer.WriteByte('}')
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON creates an object named by the discriminator field of
+// a JSON object and decodes remaining fields into it.
+func (p *Polymorphic) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		p.Type, p.Value = "", nil
+		return nil
+	}
+
+	fields := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return rterror.New("polymorphic value must be a JSON object", err)
+	}
+
+	var name string
+
+	if err := json.Unmarshal(fields[DiscriminatorField], &name); (err != nil) || (name == "") {
+		return rterror.New("polymorphic value must have a string discriminator field", DiscriminatorField)
+	}
+
+	delete(fields, DiscriminatorField)
+
+	object, err := p.create(name)
+
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(object)
+
+	if value.Kind() != reflect.Ptr {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer
+	}
+
+	if len(fields) != 0 {
+		remaining, err := json.Marshal(fields)
+
+		if err != nil {
+			return err
+		}
+
+		if err = json.Unmarshal(remaining, value.Interface()); err != nil {
+			return rterror.New("cannot decode polymorphic value", name, err)
+		}
+	}
+
+	if reflect.ValueOf(object).Kind() != reflect.Ptr {
+		object = value.Elem().Interface()
+	}
+
+	p.Type, p.Value = name, object
+
+	return nil
+}
+
+// create creates an object with a given name.
+func (p *Polymorphic) create(name string) (interface{}, error) {
+	if p.Creator != nil {
+		return p.Creator.Create(name)
+	}
+
+	return Create(name)
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64 `json:"side"`
+}
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+type Point struct{}
+
+type Drawing struct {
+	Name  string              `json:"name"`
+	Shape factory.Polymorphic `json:"shape"`
+}
+
+func (s *Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func (c Circle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
+
+func NewShapes() *factory.Factory {
+	return factory.New().
+		Set("square", func(...interface{}) (interface{}, error) {
+			return &Square{Side: 1}, nil
+		}).
+		Set("circle", func(...interface{}) (interface{}, error) {
+			return Circle{}, nil
+		}).
+		Set("point", func(...interface{}) (interface{}, error) {
+			return &Point{}, nil
+		})
+}
+
+func TestPolymorphicUnmarshal(test *testing.T) {
+	drawing := Drawing{
+		Shape: factory.Polymorphic{Creator: NewShapes()},
+	}
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"name":"a","shape":{"type":"square","side":2}}`), &drawing))
+	assert.Equal(test, "square", drawing.Shape.Type)
+	assert.Equal(test, &Square{Side: 2}, drawing.Shape.Value)
+	assert.Equal(test, 4.0, drawing.Shape.Value.(Shape).Area())
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"shape":{"type":"circle","radius":1}}`), &drawing))
+	assert.Equal(test, Circle{Radius: 1}, drawing.Shape.Value)
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"shape":{"type":"square"}}`), &drawing))
+	assert.Equal(test, &Square{Side: 1}, drawing.Shape.Value)
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"shape":null}`), &drawing))
+	assert.Nil(test, drawing.Shape.Value)
+	assert.Empty(test, drawing.Shape.Type)
+}
+
+func TestPolymorphicUnmarshalErrors(test *testing.T) {
+	for _, document := range []string{
+		`[]`,
+		`{"side":2}`,
+		`{"type":1}`,
+		`{"type":""}`,
+		`{"type":"triangle"}`,
+		`{"type":"square","side":"2"}`,
+	} {
+		value := factory.Polymorphic{Creator: NewShapes()}
+
+		assert.Error(test, json.Unmarshal([]byte(document), &value), document)
+	}
+}
+
+func TestPolymorphicMarshal(test *testing.T) {
+	for _, testCase := range []struct {
+		value    factory.Polymorphic
+		expected string
+	}{
+		{value: factory.Polymorphic{Type: "square", Value: &Square{Side: 2}}, expected: `{"type":"square","side":2}`},
+		{value: factory.Polymorphic{Type: "circle", Value: Circle{Radius: 1}}, expected: `{"type":"circle","radius":1}`},
+		{value: factory.Polymorphic{Type: "point", Value: &Point{}}, expected: `{"type":"point"}`},
+		{value: factory.Polymorphic{}, expected: `null`},
+		{value: factory.Polymorphic{Type: "square", Value: (*Square)(nil)}, expected: `null`},
+		{value: factory.Polymorphic{Type: "map", Value: map[string]interface{}(nil)}, expected: `null`},
+	} {
+		data, err := json.Marshal(testCase.value)
+
+		assert.NoError(test, err)
+		assert.JSONEq(test, testCase.expected, string(data))
+	}
+
+	data, err := json.Marshal(Drawing{Name: "empty", Shape: factory.Polymorphic{Type: "square", Value: (*Square)(nil)}})
+
+	assert.NoError(test, err)
+	assert.JSONEq(test, `{"name":"empty","shape":null}`, string(data))
+}
+
+func TestPolymorphicMarshalErrors(test *testing.T) {
+	for _, value := range []factory.Polymorphic{
+		{Value: &Square{}},
+		{Type: "number", Value: 1},
+		{Type: "string", Value: "null"},
+		{Type: "list", Value: []int{1}},
+		{Type: "map", Value: map[string]interface{}{"type": "other"}},
+		{Type: "channel", Value: make(chan int)},
+	} {
+		_, err := json.Marshal(value)
+		assert.Error(test, err)
+	}
+}
+
+func TestPolymorphicRoundTrip(test *testing.T) {
+	shapes := NewShapes()
+
+	drawing := Drawing{
+		Name:  "drawing",
+		Shape: factory.Polymorphic{Type: "square", Value: &Square{Side: 3}},
+	}
+
+	data, err := json.Marshal(drawing)
+
+	assert.NoError(test, err)
+	assert.JSONEq(test, `{"name":"drawing","shape":{"type":"square","side":3}}`, string(data))
+
+	decoded := Drawing{
+		Shape: factory.Polymorphic{Creator: shapes},
+	}
+
+	assert.NoError(test, json.Unmarshal(data, &decoded))
+	assert.Equal(test, drawing.Shape.Value, decoded.Shape.Value)
+	assert.Equal(test, drawing.Name, decoded.Name)
+}
+
+func TestPolymorphicGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("square", func(...interface{}) (interface{}, error) {
+		return &Square{}, nil
+	})
+
+	var value factory.Polymorphic
+
+	assert.NoError(test, json.Unmarshal([]byte(`{"type":"square","side":5}`), &value))
+	assert.Equal(test, &Square{Side: 5}, value.Value)
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Result defines a result of creating an object with a given name.
+type Result struct {
+	Name   string
+	Object interface{}
+	Err    error
+}
+
+// Results defines results of creating objects in order of their names.
+type Results []Result
+
+// CreatesResults creates a list of new objects based on given names. Unlike
+// Creates, it returns a result for every given name, in the same order,
+// holding either a created object or an error.
+func (f *Factory) CreatesResults(names []string, arguments ...interface{}) Results {
+	results := make(Results, len(names))
+
+	for index, name := range names {
+		object, err := f.Create(name, arguments...)
+
+		results[index] = Result{
+			Name:   name,
+			Object: object,
+			Err:    err,
+		}
+	}
+
+	return results
+}
+
+// Objects returns created objects by their names. Objects that were not
+// created are omitted.
+func (r Results) Objects() map[string]interface{} {
+	objects := make(map[string]interface{}, len(r))
+
+	for _, result := range r {
+		if result.Err == nil {
+			objects[result.Name] = result.Object
+		}
+	}
+
+	return objects
+}
+
+// Err returns an error aggregating errors of all objects that were not
+// created or nil if all objects were created.
+func (r Results) Err() error {
+	errs := make([]interface{}, 0, len(r))
+
+	for _, result := range r {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	if len(errs) != 0 {
+		return rterror.New("cannot create objects", errs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestCreatesResults(test *testing.T) {
+	f := factory.New().
+		Set("first", func(...interface{}) (interface{}, error) {
+			return "first", nil
+		}).
+		Set("error", ConstructorError).
+		Set("last", func(arguments ...interface{}) (interface{}, error) {
+			return arguments[0], nil
+		})
+
+	results := f.CreatesResults([]string{"first", "error", "unknown", "last"}, "argument")
+
+	assert.Len(test, results, 4)
+
+	assert.Equal(test, factory.Result{Name: "first", Object: "first"}, results[0])
+	assert.Equal(test, factory.Result{Name: "last", Object: "argument"}, results[3])
+
+	assert.Equal(test, "error", results[1].Name)
+	assert.Nil(test, results[1].Object)
+	assert.Error(test, results[1].Err)
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.Equal(test, "unknown", results[2].Name)
+	assert.True(test, errors.As(results[2].Err, &notRegistered))
+
+	assert.Equal(test, map[string]interface{}{"first": "first", "last": "argument"}, results.Objects())
+	assert.Error(test, results.Err())
+}
+
+func TestCreatesResultsSucceeded(test *testing.T) {
+	f := factory.New().Set("constructor", Constructor)
+
+	results := f.CreatesResults([]string{"constructor", "constructor"})
+
+	assert.Len(test, results, 2)
+	assert.NoError(test, results.Err())
+	assert.Len(test, results.Objects(), 1)
+
+	results = f.CreatesResults(nil)
+
+	assert.Empty(test, results)
+	assert.NoError(test, results.Err())
+	assert.Empty(test, results.Objects())
+}
+
+func TestCreatesResultsGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	factory.Set("constructor", Constructor)
+
+	results := factory.CreatesResults([]string{"constructor", "unknown"})
+
+	assert.Len(test, results, 2)
+	assert.NoError(test, results[0].Err)
+	assert.Error(test, results[1].Err)
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"reflect"
+	"strconv"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Argument defines a single object constructor argument.
+type Argument struct {
+	// Name is an optional argument name used in error messages.
+	Name string
+
+	// Type is a type of argument. Argument of any type is accepted if it is nil.
+	Type reflect.Type
+
+	// Optional allows argument to be omitted. Optional arguments must follow
+	// required ones.
+	Optional bool
+
+	// Default is a value passed to object constructor when optional argument
+	// was omitted.
+	Default interface{}
+}
+
+// Schema defines object constructor arguments. Arguments passed to object
+// constructor registered with a schema are validated before the object
+// constructor is called. Numeric arguments are converted to numeric argument
+// types if their values are preserved.
+type Schema []Argument
+
+// InvalidArgumentsError defines an error returned when arguments passed to
+// object constructor do not match its schema or function parameters. Position
+// is negative if the offending argument is not positional.
+type InvalidArgumentsError struct {
+	Name     string
+	Position int
+	Argument string
+	Reason   string
+}
+
+// Error returns error message with object constructor name and position of
+// the offending argument.
+func (e *InvalidArgumentsError) Error() string {
+	message := "invalid argument"
+
+	if e.Position >= 0 {
+		message += " " + strconv.Itoa(e.Position)
+	}
+
+	if e.Argument != "" {
+		message += " (" + e.Argument + ")"
+	}
+
+	if e.Name != "" {
+		message += " of " + e.Name
+	}
+
+	return message + ": " + e.Reason
+}
+
+// AddWithSchema adds an object constructor with a given unique id and
+// argument schema to registry.
+func (f *Factory) AddWithSchema(name string, constructor Constructor, schema Schema) error {
+	if err := schema.validate(); err != nil {
+		return err
+	}
+
+	return f.registry.Add(name, &registration{
+		constructor: constructor,
+		schema:      schema,
+	})
+}
+
+// SetWithSchema sets an object constructor with a given unique id and
+// argument schema to registry.
+func (f *Factory) SetWithSchema(name string, constructor Constructor, schema Schema) *Factory {
+	if err := schema.validate(); err != nil {
+		panic(err)
+	}
+
+	f.registry.Set(name, &registration{
+		constructor: constructor,
+		schema:      schema,
+	})
+
+	return f
+}
+
+// SchemaOf returns argument schema of registered object constructor. It is
+// nil if object constructor was registered without a schema.
+func (f *Factory) SchemaOf(name string) (Schema, error) {
+	object, err := f.registry.Get(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaOf(object), nil
+}
+
+// validate returns an error if schema is malformed.
+func (s Schema) validate() error {
+	optional := false
+
+	for position, argument := range s {
+		if optional && !argument.Optional {
+			return rterror.New("required argument cannot follow optional one", position, argument.Name)
+		}
+
+		optional = argument.Optional
+
+		if (argument.Type == nil) || !argument.Optional {
+			continue
+		}
+
+		if _, ok := convertArgument(argument.Default, argument.Type); !ok && (argument.Default != nil) {
+			return rterror.New("default value does not match argument type", position, argument.Name,
+				argument.Type.String())
+		}
+	}
+
+	return nil
+}
+
+// apply validates given arguments and returns them converted to argument
+// types with default values of omitted optional arguments.
+func (s Schema) apply(name string, arguments []interface{}) ([]interface{}, error) {
+	if s == nil {
+		return arguments, nil
+	}
+
+	if len(arguments) > len(s) {
+		return nil, invalidArguments(&InvalidArgumentsError{
+			Name:     name,
+			Position: len(s),
+			Reason:   "too many arguments, expected at most " + strconv.Itoa(len(s)),
+		})
+	}
+
+	values := make([]interface{}, len(s))
+
+	for position, argument := range s {
+		if position >= len(arguments) {
+			if !argument.Optional {
+				return nil, invalidArguments(&InvalidArgumentsError{
+					Name:     name,
+					Position: position,
+					Argument: argument.Name,
+					Reason:   "required argument is missing",
+				})
+			}
+
+			values[position] = argument.defaultValue()
+
+			continue
+		}
+
+		value, err := argument.convert(arguments[position])
+
+		if err != nil {
+			err.Name, err.Position = name, position
+			return nil, invalidArguments(err)
+		}
+
+		values[position] = value
+	}
+
+	return values, nil
+}
+
+// convert converts a given value to argument type.
+func (a *Argument) convert(value interface{}) (interface{}, *InvalidArgumentsError) {
+	if a.Type == nil {
+		return value, nil
+	}
+
+	converted, ok := convertArgument(value, a.Type)
+
+	if !ok {
+		return nil, &InvalidArgumentsError{
+			Argument: a.Name,
+			Reason:   "cannot use " + typeString(value) + " as " + a.Type.String(),
+		}
+	}
+
+	return converted.Interface(), nil
+}
+
+// defaultValue returns default value of argument converted to argument type.
+// It is zero value of argument type if default value was not set.
+func (a *Argument) defaultValue() interface{} {
+	if a.Type == nil {
+		return a.Default
+	}
+
+	if a.Default == nil {
+		return reflect.Zero(a.Type).Interface()
+	}
+
+	converted, _ := convertArgument(a.Default, a.Type)
+
+	return converted.Interface()
+}
+
+// invalidArguments returns a runtime error wrapping a given invalid arguments error.
+func invalidArguments(err *InvalidArgumentsError) error {
+	return rterror.NewSkipCaller(1, err.Error(), err)
+}
+
+// schemaOf returns argument schema of a given registry object.
+func schemaOf(object interface{}) Schema {
+	if r, ok := object.(*registration); ok {
+		return r.schema
+	}
+
+	return nil
+}
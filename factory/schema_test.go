@@ -0,0 +1,193 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// nolint: gochecknoglobals
+var ServerSchema = factory.Schema{
+	{Name: "address", Type: reflect.TypeOf("")},
+	{Name: "port", Type: reflect.TypeOf(0), Optional: true, Default: 80},
+	{Name: "timeout", Type: reflect.TypeOf(time.Duration(0)), Optional: true},
+}
+
+func ServerConstructor(arguments ...interface{}) (interface{}, error) {
+	return &server{
+		address: arguments[0].(string),
+		port:    arguments[1].(int),
+		timeout: arguments[2].(time.Duration),
+	}, nil
+}
+
+func TestSchemaCreate(test *testing.T) {
+	f := factory.New()
+
+	assert.NoError(test, f.AddWithSchema("server", ServerConstructor, ServerSchema))
+	assert.Error(test, f.AddWithSchema("server", ServerConstructor, ServerSchema))
+
+	object, err := f.Create("server", "localhost")
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 80}, object)
+
+	object, err = f.Create("server", "localhost", uint8(8), 5)
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8, timeout: 5}, object)
+
+	schema, err := f.SchemaOf("server")
+
+	assert.NoError(test, err)
+	assert.Equal(test, ServerSchema, schema)
+}
+
+func TestSchemaInvalidArguments(test *testing.T) {
+	f := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	for _, testCase := range []struct {
+		arguments []interface{}
+		position  int
+		argument  string
+	}{
+		{arguments: []interface{}{}, position: 0, argument: "address"},
+		{arguments: []interface{}{1}, position: 0, argument: "address"},
+		{arguments: []interface{}{"localhost", "80"}, position: 1, argument: "port"},
+		{arguments: []interface{}{"localhost", 80, time.Second, 1}, position: 3},
+	} {
+		_, err := f.Create("server", testCase.arguments...)
+
+		var invalid *factory.InvalidArgumentsError
+
+		assert.True(test, errors.As(err, &invalid))
+		assert.Equal(test, "server", invalid.Name)
+		assert.Equal(test, testCase.position, invalid.Position)
+		assert.Equal(test, testCase.argument, invalid.Argument)
+		assert.Contains(test, err.Error(), "of server")
+	}
+}
+
+func TestSchemaSignConversions(test *testing.T) {
+	f := factory.New().SetWithSchema("server", ServerConstructor, factory.Schema{
+		{Name: "address", Type: reflect.TypeOf("")},
+		{Name: "port", Type: reflect.TypeOf(uint16(0))},
+		{Name: "timeout", Type: reflect.TypeOf(time.Duration(0))},
+	})
+
+	for _, testCase := range []struct {
+		arguments []interface{}
+		position  int
+	}{
+		{arguments: []interface{}{"localhost", -1, 0}, position: 1},
+		{arguments: []interface{}{"localhost", int16(-1), 0}, position: 1},
+		{arguments: []interface{}{"localhost", 80, uint64(math.MaxUint64)}, position: 2},
+	} {
+		_, err := f.Create("server", testCase.arguments...)
+
+		var invalid *factory.InvalidArgumentsError
+
+		assert.True(test, errors.As(err, &invalid), "%v", testCase.arguments)
+		assert.Equal(test, testCase.position, invalid.Position)
+	}
+
+	assert.Error(test, f.AddWithSchema("invalid", ServerConstructor, factory.Schema{
+		{Name: "port", Type: reflect.TypeOf(uint16(0)), Optional: true, Default: -1},
+	}))
+}
+
+func TestSchemaInvalid(test *testing.T) {
+	f := factory.New()
+
+	assert.Error(test, f.AddWithSchema("server", ServerConstructor, factory.Schema{
+		{Name: "port", Optional: true},
+		{Name: "address"},
+	}))
+
+	assert.Error(test, f.AddWithSchema("server", ServerConstructor, factory.Schema{
+		{Name: "port", Type: reflect.TypeOf(0), Optional: true, Default: "80"},
+	}))
+
+	assert.False(test, f.IsExist("server"))
+
+	assert.Panics(test, func() {
+		f.SetWithSchema("server", ServerConstructor, factory.Schema{
+			{Name: "port", Optional: true},
+			{Name: "address"},
+		})
+	})
+}
+
+func TestSchemaUntyped(test *testing.T) {
+	f := factory.New().SetWithSchema("any", func(arguments ...interface{}) (interface{}, error) {
+		return arguments, nil
+	}, factory.Schema{
+		{Name: "value"},
+		{Name: "other", Optional: true, Default: "default"},
+	})
+
+	object, err := f.Create("any", 1)
+
+	assert.NoError(test, err)
+	assert.Equal(test, []interface{}{1, "default"}, object)
+}
+
+func TestSchemaNotRegistered(test *testing.T) {
+	f := factory.New().Set("constructor", Constructor)
+
+	schema, err := f.SchemaOf("constructor")
+
+	assert.NoError(test, err)
+	assert.Nil(test, schema)
+
+	_, err = f.SchemaOf("unknown")
+	assert.Error(test, err)
+}
+
+func TestSchemaDiff(test *testing.T) {
+	a := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+	b := factory.New().SetWithSchema("server", ServerConstructor, ServerSchema)
+
+	assert.True(test, factory.Diff(a, b, nil).IsEmpty())
+
+	b.SetWithSchema("server", ServerConstructor, ServerSchema[:1])
+
+	assert.Equal(test, registry.Names{"server"}, factory.Diff(a, b, nil).Changed)
+}
+
+func TestSchemaGlobal(test *testing.T) {
+	defer factory.RemoveAll()
+
+	assert.NoError(test, factory.AddWithSchema("server", ServerConstructor, ServerSchema))
+	factory.SetWithSchema("other", ServerConstructor, ServerSchema)
+
+	schema, err := factory.SchemaOf("other")
+
+	assert.NoError(test, err)
+	assert.Equal(test, ServerSchema, schema)
+
+	object, err := factory.Create("server", "localhost", 8080)
+
+	assert.NoError(test, err)
+	assert.Equal(test, &server{address: "localhost", port: 8080}, object)
+}
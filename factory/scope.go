@@ -0,0 +1,193 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Scope defines a scope of objects created by a factory. Objects registered
+// with the scoped lifetime are created once per scope and closed together with
+// the scope if they implement the io.Closer interface. Objects with other
+// lifetimes are created as by the factory. Scope is safe for concurrent use.
+type Scope struct {
+	read    func(function func(f *Factory))
+	mutex   sync.Mutex
+	objects map[string]interface{}
+	pending map[string]*pending
+	closers []io.Closer
+	closed  bool
+}
+
+// NewScope creates a new scope of objects created by factory.
+func (f *Factory) NewScope() *Scope {
+	return newScope(func(function func(f *Factory)) {
+		function(f)
+	})
+}
+
+// Create creates a new object based on given name or returns the scoped
+// object already created within scope.
+func (s *Scope) Create(name string, arguments ...interface{}) (object interface{}, err error) {
+	s.read(func(f *Factory) {
+		start := time.Now()
+		object, err = f.create(context.Background(), s, name, arguments...)
+		f.record(name, start, err)
+	})
+
+	return object, err
+}
+
+// Creates creates a list of new objects based on given names or returns the
+// scoped objects already created within scope.
+func (s *Scope) Creates(names []string, arguments ...interface{}) ([]interface{}, error) {
+	objects := make([]interface{}, 0, len(names))
+	errs := make([]interface{}, 0, len(names))
+
+	for _, name := range names {
+		object, err := s.Create(name, arguments...)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	if len(errs) != 0 {
+		return objects, rterror.New("cannot create objects", errs...)
+	}
+
+	return objects, nil
+}
+
+// Close closes all scoped objects created within scope that implement the
+// io.Closer interface, in reverse order of their creation. Objects cannot be
+// created within a closed scope.
+func (s *Scope) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	errs := make([]interface{}, 0, len(s.closers))
+
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if err := s.closers[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	s.objects = nil
+	s.closers = nil
+
+	if len(errs) != 0 {
+		return rterror.New("cannot close scoped objects", errs...)
+	}
+
+	return nil
+}
+
+// scoped returns the scoped object created within scope. The object is
+// created on first successful call.
+func (s *Scope) scoped(ctx context.Context, f *Factory, name string, r *registration,
+	arguments ...interface{}) (object interface{}, err error) {
+	key, err := f.registry.Key(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+
+	if s.closed {
+		s.mutex.Unlock()
+		return nil, rterror.New("scope was closed", name)
+	}
+
+	if object, ok := s.objects[key]; ok {
+		s.mutex.Unlock()
+		return object, nil
+	}
+
+	if p, ok := s.pending[key]; ok {
+		s.mutex.Unlock()
+		return p.wait(ctx, name)
+	}
+
+	p := newPending()
+	s.pending[key] = p
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.pending, key)
+
+		switch {
+		case err != nil:
+		case s.closed:
+			// Scope was closed meanwhile, nobody else would close the object
+			if closer, ok := object.(io.Closer); ok {
+				closer.Close()
+			}
+
+			object, err = nil, rterror.New("scope was closed", name)
+		default:
+			s.objects[key] = object
+
+			if closer, ok := object.(io.Closer); ok {
+				s.closers = append(s.closers, closer)
+			}
+		}
+
+		s.mutex.Unlock()
+
+		p.finish(object, err)
+	}()
+
+	// Object is created without holding the lock, so its constructor can
+	// create other objects within the same scope. Waiting requests get an
+	// error if it panics.
+	err = rterror.New("object constructor panicked", name)
+
+	return f.construct(ctx, name, contextConstructorOf(r), arguments...)
+}
+
+// isClosed returns true if scope was closed.
+func (s *Scope) isClosed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.closed
+}
+
+// newScope creates a new scope with a given function providing locked factory.
+func newScope(read func(function func(f *Factory))) *Scope {
+	return &Scope{
+		read:    read,
+		objects: map[string]interface{}{},
+		pending: map[string]*pending{},
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package factory_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type closer struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *closer) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func closerConstructor(name string, closed *[]string, err error) factory.Constructor {
+	return func(...interface{}) (interface{}, error) {
+		return &closer{name: name, closed: closed, err: err}, nil
+	}
+}
+
+func TestScopeCreate(test *testing.T) {
+	var count int64
+
+	f := factory.New(registry.WithNormalizer(registry.FoldCase))
+	f.SetWithLifetime("scoped", counter(&count), factory.Scoped)
+	f.SetWithLifetime("singleton", counter(&count), factory.Singleton)
+	f.Set("transient", counter(&count))
+
+	first := f.NewScope()
+	second := f.NewScope()
+
+	a, err := first.Create("scoped")
+	assert.NoError(test, err)
+
+	b, err := first.Create("SCOPED")
+	assert.NoError(test, err)
+
+	c, err := second.Create("scoped")
+	assert.NoError(test, err)
+
+	assert.Same(test, a, b)
+	assert.NotSame(test, a, c)
+
+	a, err = first.Create("singleton")
+	assert.NoError(test, err)
+
+	b, err = second.Create("singleton")
+	assert.NoError(test, err)
+
+	assert.Same(test, a, b)
+
+	a, err = first.Create("transient")
+	assert.NoError(test, err)
+
+	b, err = first.Create("transient")
+	assert.NoError(test, err)
+
+	assert.NotSame(test, a, b)
+
+	objects, err := first.Creates([]string{"scoped", "singleton", "unknown"})
+
+	assert.Error(test, err)
+	assert.Len(test, objects, 2)
+}
+
+func TestScopeClose(test *testing.T) {
+	closed := []string{}
+
+	f := factory.New()
+	f.SetWithLifetime("first", closerConstructor("first", &closed, nil), factory.Scoped)
+	f.SetWithLifetime("second", closerConstructor("second", &closed, nil), factory.Scoped)
+	f.SetWithLifetime("object", Constructor, factory.Scoped)
+	f.Set("transient", closerConstructor("transient", &closed, nil))
+
+	scope := f.NewScope()
+
+	_, err := scope.Creates([]string{"first", "second", "object", "transient", "first"})
+	assert.NoError(test, err)
+
+	assert.NoError(test, scope.Close())
+	assert.Equal(test, []string{"second", "first"}, closed)
+
+	assert.NoError(test, scope.Close())
+	assert.Len(test, closed, 2)
+
+	_, err = scope.Create("first")
+	assert.Error(test, err)
+
+	_, err = scope.Create("transient")
+	assert.Error(test, err)
+}
+
+func TestScopeCloseError(test *testing.T) {
+	closed := []string{}
+	failure := errors.New("failure")
+
+	f := factory.New()
+	f.SetWithLifetime("first", closerConstructor("first", &closed, failure), factory.Scoped)
+	f.SetWithLifetime("second", closerConstructor("second", &closed, nil), factory.Scoped)
+
+	scope := f.NewScope()
+
+	_, err := scope.Creates([]string{"first", "second"})
+	assert.NoError(test, err)
+
+	err = scope.Close()
+
+	assert.Error(test, err)
+	assert.True(test, errors.Is(err, failure))
+	assert.Equal(test, []string{"second", "first"}, closed)
+}
+
+func TestScopeCreateError(test *testing.T) {
+	f := factory.New().SetWithLifetime("scoped", ConstructorError, factory.Scoped)
+
+	scope := f.NewScope()
+	defer scope.Close()
+
+	_, err := scope.Create("scoped")
+	assert.Error(test, err)
+
+	_, err = scope.Create("unknown")
+	assert.Error(test, err)
+}
+
+func TestScopeCreateNested(test *testing.T) {
+	var count int64
+
+	f := factory.New()
+	scope := f.NewScope()
+
+	defer scope.Close()
+
+	f.SetWithLifetime("db", counter(&count), factory.Scoped)
+	f.SetWithLifetime("repository", func(...interface{}) (interface{}, error) {
+		return scope.Create("db")
+	}, factory.Scoped)
+
+	repository, err := scope.Create("repository")
+	assert.NoError(test, err)
+
+	db, err := scope.Create("db")
+	assert.NoError(test, err)
+
+	assert.Same(test, db, repository)
+	assert.Equal(test, int64(1), count)
+}
+
+func TestScopeCreateConcurrent(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("scoped", counter(&count), factory.Scoped)
+	scope := f.NewScope()
+
+	defer scope.Close()
+
+	objects := make([]interface{}, 16)
+
+	var wait sync.WaitGroup
+
+	for i := range objects {
+		wait.Add(1)
+
+		go func(index int) {
+			defer wait.Done()
+
+			objects[index], _ = scope.Create("scoped")
+		}(i)
+	}
+
+	wait.Wait()
+
+	for _, object := range objects {
+		assert.Same(test, objects[0], object)
+	}
+
+	assert.Equal(test, int64(1), count)
+}
+
+func TestScopeCloseWhileCreating(test *testing.T) {
+	var closed []string
+
+	f := factory.New()
+	scope := f.NewScope()
+
+	f.SetWithLifetime("scoped", func(...interface{}) (interface{}, error) {
+		assert.NoError(test, scope.Close())
+		return &closer{name: "scoped", closed: &closed}, nil
+	}, factory.Scoped)
+
+	object, err := scope.Create("scoped")
+
+	assert.Error(test, err)
+	assert.Nil(test, object)
+	assert.Equal(test, []string{"scoped"}, closed)
+}
+
+func TestScopeCreatePanic(test *testing.T) {
+	var count int64
+
+	f := factory.New().SetWithLifetime("scoped", func(arguments ...interface{}) (interface{}, error) {
+		if atomic.AddInt64(&count, 1) == 1 {
+			panic("failure")
+		}
+
+		return &service{id: count}, nil
+	}, factory.Scoped)
+
+	scope := f.NewScope()
+	defer scope.Close()
+
+	assert.Panics(test, func() { _, _ = scope.Create("scoped") })
+
+	object, err := scope.Create("scoped")
+
+	assert.NoError(test, err)
+	assert.Equal(test, &service{id: 2}, object)
+}
@@ -0,0 +1,247 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// Container defines a dependency injection container. Every registered object
+// constructor declares names of its dependencies. When an object is resolved,
+// its dependencies are resolved first, in topological order, and passed to its
+// constructor as arguments in declaration order. Dependencies of singleton
+// and scoped objects that were already created are not resolved again.
+type Container struct {
+	factory      *factory.Factory
+	dependencies *registry.Registry
+}
+
+// creator defines an object creator used to resolve objects.
+type creator interface {
+	Create(name string, arguments ...interface{}) (interface{}, error)
+}
+
+// New creates a new dependency injection container. Given registry options
+// are applied to the registry of object constructors and their dependencies.
+func New(options ...registry.Option) *Container {
+	return &Container{
+		factory:      factory.New(options...),
+		dependencies: registry.New(options...),
+	}
+}
+
+// Add adds an object constructor with a given unique id and names of its
+// dependencies to container.
+func (c *Container) Add(name string, constructor factory.Constructor, dependencies ...string) error {
+	return c.AddWithLifetime(name, constructor, factory.Transient, dependencies...)
+}
+
+// AddWithLifetime adds an object constructor with a given unique id, object
+// lifetime and names of its dependencies to container.
+func (c *Container) AddWithLifetime(name string, constructor factory.Constructor, lifetime factory.Lifetime,
+	dependencies ...string) error {
+	if err := c.factory.AddWithLifetime(name, inject(constructor), lifetime); err != nil {
+		return err
+	}
+
+	c.dependencies.Set(name, registry.Names(dependencies))
+
+	return nil
+}
+
+// Set sets an object constructor with a given unique id and names of its
+// dependencies to container.
+func (c *Container) Set(name string, constructor factory.Constructor, dependencies ...string) *Container {
+	return c.SetWithLifetime(name, constructor, factory.Transient, dependencies...)
+}
+
+// SetWithLifetime sets an object constructor with a given unique id, object
+// lifetime and names of its dependencies to container.
+func (c *Container) SetWithLifetime(name string, constructor factory.Constructor, lifetime factory.Lifetime,
+	dependencies ...string) *Container {
+	c.factory.SetWithLifetime(name, inject(constructor), lifetime)
+	c.dependencies.Set(name, registry.Names(dependencies))
+
+	return c
+}
+
+// Resolve creates an object with a given name and all its dependencies.
+// Dependencies shared by many objects are created once per resolution.
+func (c *Container) Resolve(name string) (interface{}, error) {
+	return c.newResolution(c.factory).resolve(name)
+}
+
+// Dependencies returns names of dependencies of a registered object.
+func (c *Container) Dependencies(name string) (registry.Names, error) {
+	object, err := c.dependencies.Get(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(registry.Names{}, object.(registry.Names)...), nil
+}
+
+// Remove removes registered object constructor and its dependencies.
+func (c *Container) Remove(name string) *Container {
+	c.factory.Remove(name)
+	c.dependencies.Remove(name)
+
+	return c
+}
+
+// IsExist returns true if object constructor with given name was registered, otherwise it returns false.
+func (c *Container) IsExist(name string) bool {
+	return c.factory.IsExist(name)
+}
+
+// Size returns number of registered object constructors.
+func (c *Container) Size() int {
+	return c.factory.Size()
+}
+
+// resolution defines a single resolution of an object and its dependencies.
+type resolution struct {
+	container *Container
+	creator   creator
+	objects   map[string]interface{}
+	path      registry.Names
+}
+
+// newResolution creates a new resolution using a given object creator.
+func (c *Container) newResolution(creator creator) *resolution {
+	return &resolution{
+		container: c,
+		creator:   creator,
+		objects:   map[string]interface{}{},
+	}
+}
+
+// request defines a request to create an object within a resolution. It is
+// passed to object constructors registered in the container factory.
+type request struct {
+	resolution *resolution
+	name       string
+	err        error
+}
+
+// inject returns an object constructor resolving object dependencies right
+// before calling a given constructor with them. Dependencies of singleton and
+// scoped objects that were already created are not resolved again.
+func inject(constructor factory.Constructor) factory.Constructor {
+	if constructor == nil {
+		return nil
+	}
+
+	return func(arguments ...interface{}) (interface{}, error) {
+		req := arguments[0].(*request)
+
+		dependencies, err := req.resolution.dependencies(req.name)
+
+		if err != nil {
+			req.err = err
+			return nil, err
+		}
+
+		return constructor(dependencies...)
+	}
+}
+
+// resolve creates an object with a given name after all its dependencies.
+func (r *resolution) resolve(name string) (interface{}, error) {
+	key, err := r.container.dependencies.Key(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if object, ok := r.objects[key]; ok {
+		return object, nil
+	}
+
+	if err = r.enter(key); err != nil {
+		return nil, err
+	}
+
+	defer r.leave()
+
+	req := &request{
+		resolution: r,
+		name:       key,
+	}
+
+	object, err := r.creator.Create(key, req)
+
+	// Dependency errors are returned as they are, not wrapped by factory
+	if req.err != nil {
+		return nil, req.err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.objects[key] = object
+
+	return object, nil
+}
+
+// dependencies resolves dependencies of an object with a given name.
+func (r *resolution) dependencies(name string) ([]interface{}, error) {
+	dependencies, err := r.container.Dependencies(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]interface{}, 0, len(dependencies))
+
+	for _, dependency := range dependencies {
+		object, err := r.resolve(dependency)
+
+		if err != nil {
+			return nil, dependencyError(name, dependency, err)
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// enter appends a given name to resolution path. It returns an error if name
+// is already on the path.
+func (r *resolution) enter(name string) error {
+	for index, visited := range r.path {
+		if visited == name {
+			cycle := &CycleError{
+				Path: append(append(registry.Names{}, r.path[index:]...), name),
+			}
+
+			return rterror.New(cycle.Error(), cycle)
+		}
+	}
+
+	r.path = append(r.path, name)
+
+	return nil
+}
+
+// leave removes the last name from resolution path.
+func (r *resolution) leave() {
+	r.path = r.path[:len(r.path)-1]
+}
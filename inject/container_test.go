@@ -0,0 +1,185 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/inject"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type node struct {
+	name         string
+	dependencies []interface{}
+}
+
+func newNode(name string, created *[]string) factory.Constructor {
+	return func(arguments ...interface{}) (interface{}, error) {
+		*created = append(*created, name)
+
+		return &node{name: name, dependencies: arguments}, nil
+	}
+}
+
+func TestContainerResolve(test *testing.T) {
+	created := []string{}
+
+	c := inject.New()
+
+	assert.NoError(test, c.Add("service", newNode("service", &created), "repository", "logger"))
+	assert.NoError(test, c.Add("repository", newNode("repository", &created), "database", "logger"))
+	assert.NoError(test, c.Add("database", newNode("database", &created), "config"))
+	assert.NoError(test, c.Add("logger", newNode("logger", &created), "config"))
+	assert.NoError(test, c.Add("config", newNode("config", &created)))
+
+	object, err := c.Resolve("service")
+
+	assert.NoError(test, err)
+	assert.Equal(test, []string{"config", "database", "logger", "repository", "service"}, created)
+
+	service := object.(*node)
+
+	assert.Len(test, service.dependencies, 2)
+	assert.Equal(test, "repository", service.dependencies[0].(*node).name)
+	assert.Equal(test, "logger", service.dependencies[1].(*node).name)
+	assert.Same(test, service.dependencies[1], service.dependencies[0].(*node).dependencies[1])
+}
+
+func TestContainerAdd(test *testing.T) {
+	c := inject.New(registry.WithNormalizer(registry.FoldCase))
+
+	assert.NoError(test, c.Add("Service", newNode("service", &[]string{}), "logger"))
+	assert.Error(test, c.Add("service", newNode("service", &[]string{})))
+
+	dependencies, err := c.Dependencies("SERVICE")
+
+	assert.NoError(test, err)
+	assert.Equal(test, registry.Names{"logger"}, dependencies)
+	assert.True(test, c.IsExist("service"))
+	assert.Equal(test, 1, c.Size())
+
+	c.Remove("service")
+
+	assert.False(test, c.IsExist("service"))
+	assert.Equal(test, 0, c.Size())
+
+	_, err = c.Dependencies("service")
+	assert.Error(test, err)
+}
+
+func TestContainerMissingDependency(test *testing.T) {
+	c := inject.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).
+		Set("service", newNode("service", &[]string{}), "loger")
+
+	c.Set("logger", newNode("logger", &[]string{}))
+
+	_, err := c.Resolve("service")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.Error(test, err)
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, "loger", notRegistered.Name)
+	assert.Equal(test, registry.Names{"logger"}, notRegistered.Suggestions)
+}
+
+func TestContainerCycle(test *testing.T) {
+	created := []string{}
+
+	c := inject.New().
+		Set("a", newNode("a", &created), "b").
+		Set("b", newNode("b", &created), "c").
+		Set("c", newNode("c", &created), "a")
+
+	_, err := c.Resolve("a")
+
+	var cycle *inject.CycleError
+
+	assert.True(test, errors.As(err, &cycle))
+	assert.Equal(test, registry.Names{"a", "b", "c", "a"}, cycle.Path)
+	assert.Contains(test, err.Error(), "a -> b -> c -> a")
+	assert.Empty(test, created)
+}
+
+func TestContainerSelfCycle(test *testing.T) {
+	c := inject.New().Set("a", newNode("a", &[]string{}), "a")
+
+	_, err := c.Resolve("a")
+
+	var cycle *inject.CycleError
+
+	assert.True(test, errors.As(err, &cycle))
+	assert.Equal(test, registry.Names{"a", "a"}, cycle.Path)
+}
+
+func TestContainerSingletonDependencies(test *testing.T) {
+	created := []string{}
+
+	c := inject.New().
+		SetWithLifetime("service", newNode("service", &created), factory.Singleton, "connection").
+		Set("connection", newNode("connection", &created))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Resolve("service")
+		assert.NoError(test, err)
+	}
+
+	assert.Equal(test, []string{"connection", "service"}, created)
+}
+
+func TestContainerConstructorError(test *testing.T) {
+	failure := errors.New("failure")
+
+	c := inject.New().
+		Set("service", newNode("service", &[]string{}), "database").
+		Set("database", func(...interface{}) (interface{}, error) {
+			return nil, failure
+		})
+
+	_, err := c.Resolve("service")
+
+	assert.True(test, errors.Is(err, failure))
+}
+
+func TestContainerLifetime(test *testing.T) {
+	created := []string{}
+
+	c := inject.New().
+		SetWithLifetime("config", newNode("config", &created), factory.Singleton).
+		Set("service", newNode("service", &created), "config")
+
+	first, err := c.Resolve("service")
+	assert.NoError(test, err)
+
+	second, err := c.Resolve("service")
+	assert.NoError(test, err)
+
+	assert.NotSame(test, first, second)
+	assert.Same(test, first.(*node).dependencies[0], second.(*node).dependencies[0])
+	assert.Equal(test, []string{"config", "service", "service"}, created)
+
+	third, err := c.Resolve("config")
+	assert.NoError(test, err)
+
+	assert.Same(test, first.(*node).dependencies[0], third)
+	assert.Equal(test, []string{"config", "service", "service"}, created)
+
+	assert.Error(test, c.AddWithLifetime("database", newNode("database", &created), factory.Lifetime(10)))
+	assert.False(test, c.IsExist("database"))
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// CycleError defines an error returned when dependencies form a cycle. Path
+// starts and ends with the same name.
+type CycleError struct {
+	Path registry.Names
+}
+
+// Error returns error message with the dependency cycle path.
+func (e *CycleError) Error() string {
+	return "dependency cycle detected " + strings.Join(e.Path, " -> ")
+}
+
+// Order returns names of given registered objects and all their dependencies
+// in topological order, dependencies first. If no names are given, all
+// registered objects are ordered. It returns an error if a dependency was not
+// registered or dependencies form a cycle.
+func (c *Container) Order(names ...string) (registry.Names, error) {
+	if len(names) == 0 {
+		names = c.names()
+	}
+
+	o := &ordering{
+		resolution: c.newResolution(nil),
+		order:      registry.Names{},
+	}
+
+	for _, name := range names {
+		if err := o.visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return o.order, nil
+}
+
+// WriteDOT writes the dependency graph of all registered objects in the
+// Graphviz DOT format.
+func (c *Container) WriteDOT(writer io.Writer) error {
+	buffer := bufio.NewWriter(writer)
+
+	buffer.WriteString("digraph dependencies {\n")
+
+	for _, name := range c.names() {
+		buffer.WriteString("\t" + strconv.Quote(name) + ";\n")
+
+		dependencies, _ := c.Dependencies(name)
+
+		for _, dependency := range dependencies {
+			if key, err := c.dependencies.Key(dependency); err == nil {
+				dependency = key
+			}
+
+			buffer.WriteString("\t" + strconv.Quote(name) + " -> " + strconv.Quote(dependency) + ";\n")
+		}
+	}
+
+	buffer.WriteString("}\n")
+
+	return buffer.Flush()
+}
+
+// names returns sorted names of all registered objects.
+func (c *Container) names() registry.Names {
+	objects := c.dependencies.GetAll()
+	names := make(registry.Names, 0, len(objects))
+
+	for name := range objects {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ordering defines a topological ordering of registered objects.
+type ordering struct {
+	*resolution
+	order registry.Names
+}
+
+// visit appends a given name to order after all its dependencies.
+func (o *ordering) visit(name string) error {
+	key, err := o.container.dependencies.Key(name)
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := o.objects[key]; ok {
+		return nil
+	}
+
+	if err = o.enter(key); err != nil {
+		return err
+	}
+
+	defer o.leave()
+
+	dependencies, err := o.container.Dependencies(key)
+
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if err = o.visit(dependency); err != nil {
+			return dependencyError(key, dependency, err)
+		}
+	}
+
+	o.objects[key] = nil
+	o.order = append(o.order, key)
+
+	return nil
+}
+
+// dependencyError returns an error of dependency resolution. Dependency cycle
+// errors are returned unchanged to keep the cycle path in the error message.
+func dependencyError(name, dependency string, err error) error {
+	var cycle *CycleError
+
+	if errors.As(err, &cycle) {
+		return err
+	}
+
+	return rterror.NewSkipCaller(1, "cannot resolve dependency", name, dependency, err)
+}
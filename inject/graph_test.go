@@ -0,0 +1,87 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/inject"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestGraphOrder(test *testing.T) {
+	created := []string{}
+
+	c := inject.New().
+		Set("service", newNode("service", &created), "repository", "logger").
+		Set("repository", newNode("repository", &created), "database").
+		Set("database", newNode("database", &created), "config").
+		Set("logger", newNode("logger", &created)).
+		Set("config", newNode("config", &created)).
+		Set("other", newNode("other", &created))
+
+	order, err := c.Order("service")
+
+	assert.NoError(test, err)
+	assert.Equal(test, registry.Names{"config", "database", "repository", "logger", "service"}, order)
+
+	order, err = c.Order()
+
+	assert.NoError(test, err)
+	assert.Equal(test, registry.Names{"config", "database", "logger", "other", "repository", "service"}, order)
+	assert.Empty(test, created)
+}
+
+func TestGraphOrderErrors(test *testing.T) {
+	c := inject.New().
+		Set("a", newNode("a", &[]string{}), "b").
+		Set("b", newNode("b", &[]string{}), "a").
+		Set("c", newNode("c", &[]string{}), "d")
+
+	_, err := c.Order("a")
+
+	var cycle *inject.CycleError
+
+	assert.True(test, errors.As(err, &cycle))
+	assert.Equal(test, registry.Names{"a", "b", "a"}, cycle.Path)
+
+	_, err = c.Order("c")
+	assert.Error(test, err)
+
+	_, err = c.Order()
+	assert.Error(test, err)
+}
+
+func TestGraphWriteDOT(test *testing.T) {
+	c := inject.New(registry.WithNormalizer(registry.FoldCase)).
+		Set("service", newNode("service", &[]string{}), "Repository", "logger").
+		Set("repository", newNode("repository", &[]string{})).
+		Set("logger", newNode("logger", &[]string{}))
+
+	var buffer bytes.Buffer
+
+	assert.NoError(test, c.WriteDOT(&buffer))
+	assert.Equal(test, `digraph dependencies {
+	"logger";
+	"repository";
+	"service";
+	"service" -> "repository";
+	"service" -> "logger";
+}
+`, buffer.String())
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"gitlab.com/tymonx/go-patterns/factory"
+)
+
+// Scope defines a scope of objects resolved by a container. Objects
+// registered with the scoped lifetime are created once per scope.
+type Scope struct {
+	container *Container
+	scope     *factory.Scope
+}
+
+// NewScope creates a new scope of objects resolved by container.
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		container: c,
+		scope:     c.factory.NewScope(),
+	}
+}
+
+// Resolve creates an object with a given name and all its dependencies or
+// returns scoped objects already created within scope.
+func (s *Scope) Resolve(name string) (interface{}, error) {
+	return s.container.newResolution(s.scope).resolve(name)
+}
+
+// Close closes all scoped objects created within scope.
+func (s *Scope) Close() error {
+	return s.scope.Close()
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/factory"
+	"gitlab.com/tymonx/go-patterns/inject"
+)
+
+func TestScopeResolve(test *testing.T) {
+	created := []string{}
+
+	c := inject.New().
+		SetWithLifetime("session", newNode("session", &created), factory.Scoped).
+		Set("handler", newNode("handler", &created), "session")
+
+	_, err := c.Resolve("handler")
+	assert.Error(test, err)
+
+	first := c.NewScope()
+	second := c.NewScope()
+
+	a, err := first.Resolve("handler")
+	assert.NoError(test, err)
+
+	b, err := first.Resolve("handler")
+	assert.NoError(test, err)
+
+	d, err := second.Resolve("handler")
+	assert.NoError(test, err)
+
+	assert.Same(test, a.(*node).dependencies[0], b.(*node).dependencies[0])
+	assert.Equal(test, []string{"session", "handler", "handler", "session", "handler"}, created)
+	assert.NotSame(test, a.(*node).dependencies[0], d.(*node).dependencies[0])
+
+	assert.NoError(test, first.Close())
+	assert.NoError(test, second.Close())
+
+	_, err = first.Resolve("handler")
+	assert.Error(test, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testowner
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab.com/tymonx/go-patterns/guard"
+)
+
+// Owner defines a stack of tests overriding a process-wide instance. An
+// instance can be overridden again only by the test that overrode it most
+// recently or by its subtests.
+type Owner struct {
+	prefix   string
+	instance string
+	guard    guard.Guard
+	tests    []testing.TB
+}
+
+// New creates a new owner of a process-wide instance with a given description.
+// A given prefix is used in test failure messages.
+func New(prefix, instance string) *Owner {
+	return &Owner{
+		prefix:   prefix,
+		instance: instance,
+	}
+}
+
+// Acquire marks an instance as overridden by a given test. It fails a given
+// test and returns false if an instance is already overridden by another
+// running test that is not its parent.
+func (o *Owner) Acquire(test testing.TB) bool {
+	test.Helper()
+
+	var owner testing.TB
+
+	o.guard.Write(func() {
+		if len(o.tests) != 0 {
+			owner = o.tests[len(o.tests)-1]
+
+			if (owner == test) || strings.HasPrefix(test.Name(), owner.Name()+"/") {
+				owner = nil
+			}
+		}
+
+		if owner == nil {
+			o.tests = append(o.tests, test)
+		}
+	})
+
+	if owner != nil {
+		test.Fatalf("%s: %s is already overridden by %s, tests overriding it cannot run in parallel",
+			o.prefix, o.instance, owner.Name())
+
+		return false
+	}
+
+	return true
+}
+
+// Release releases the most recent instance override.
+func (o *Owner) Release() {
+	o.guard.Write(func() {
+		o.tests = o.tests[:len(o.tests)-1]
+	})
+}
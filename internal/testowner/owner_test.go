@@ -0,0 +1,83 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testowner_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/internal/testowner"
+)
+
+type recorder struct {
+	testing.TB
+	name    string
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Name() string {
+	return r.name
+}
+
+func (r *recorder) Fatalf(format string, arguments ...interface{}) {
+	r.failure = fmt.Sprintf(format, arguments...)
+}
+
+func TestOwnerNested(test *testing.T) {
+	owner := testowner.New("registrytest", "global registry")
+	parent := &recorder{name: "TestParent"}
+	child := &recorder{name: "TestParent/child"}
+
+	assert.True(test, owner.Acquire(parent))
+	assert.True(test, owner.Acquire(parent))
+	assert.True(test, owner.Acquire(child))
+	assert.True(test, owner.Acquire(child))
+
+	assert.Empty(test, parent.failure)
+	assert.Empty(test, child.failure)
+
+	owner.Release()
+	owner.Release()
+	owner.Release()
+	owner.Release()
+}
+
+func TestOwnerParallel(test *testing.T) {
+	owner := testowner.New("registrytest", "global registry")
+	first := &recorder{name: "TestFirst"}
+	second := &recorder{name: "TestSecond"}
+	sibling := &recorder{name: "TestFirstSibling"}
+
+	assert.True(test, owner.Acquire(first))
+	assert.False(test, owner.Acquire(second))
+	assert.False(test, owner.Acquire(sibling))
+
+	assert.Empty(test, first.failure)
+	assert.Equal(test, "registrytest: global registry is already overridden by TestFirst, "+
+		"tests overriding it cannot run in parallel", second.failure)
+	assert.Contains(test, sibling.failure, "TestFirst")
+
+	owner.Release()
+
+	second.failure = ""
+
+	assert.True(test, owner.Acquire(second))
+	assert.Empty(test, second.failure)
+
+	owner.Release()
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+var gExpvarMutex sync.Mutex // nolint: gochecknoglobals
+
+// Expvar defines a metrics recorder that exports counters with the expvar
+// package under a single published map variable.
+type Expvar struct {
+	gets          *expvar.Int
+	hits          *expvar.Int
+	misses        *expvar.Int
+	adds          *expvar.Int
+	sets          *expvar.Int
+	removes       *expvar.Int
+	creates       *expvar.Map
+	createErrors  *expvar.Map
+	createSeconds *expvar.Map
+}
+
+// NewExpvar creates a new expvar metrics recorder published under a given
+// name. Recorders created with the same name share the same counters.
+// It panics if a given name was already published with a different type.
+func NewExpvar(name string) *Expvar {
+	gExpvarMutex.Lock()
+	defer gExpvarMutex.Unlock()
+
+	root, ok := expvar.Get(name).(*expvar.Map)
+
+	if !ok {
+		root = expvar.NewMap(name)
+	}
+
+	return newExpvar(root)
+}
+
+// NewLocalExpvar creates a new expvar metrics recorder with its own counters
+// that are not published.
+func NewLocalExpvar() *Expvar {
+	return newExpvar(new(expvar.Map).Init())
+}
+
+// newExpvar creates a new expvar metrics recorder with counters stored in
+// a given map.
+func newExpvar(root *expvar.Map) *Expvar {
+	return &Expvar{
+		gets:          getInt(root, "gets"),
+		hits:          getInt(root, "hits"),
+		misses:        getInt(root, "misses"),
+		adds:          getInt(root, "adds"),
+		sets:          getInt(root, "sets"),
+		removes:       getInt(root, "removes"),
+		creates:       getMap(root, "creates"),
+		createErrors:  getMap(root, "create_errors"),
+		createSeconds: getMap(root, "create_seconds"),
+	}
+}
+
+// Get records a lookup of object with a given name.
+func (e *Expvar) Get(_ string, hit bool) {
+	e.gets.Add(1)
+
+	if hit {
+		e.hits.Add(1)
+	} else {
+		e.misses.Add(1)
+	}
+}
+
+// Add records an addition of object with a given name.
+func (e *Expvar) Add(string) {
+	e.adds.Add(1)
+}
+
+// Set records a setting of object with a given name.
+func (e *Expvar) Set(string) {
+	e.sets.Add(1)
+}
+
+// Remove records a removal of object with a given name.
+func (e *Expvar) Remove(string) {
+	e.removes.Add(1)
+}
+
+// Create records a creation of object with a given name.
+func (e *Expvar) Create(name string, duration time.Duration, err error) {
+	e.creates.Add(name, 1)
+	e.createSeconds.AddFloat(name, duration.Seconds())
+
+	if err != nil {
+		e.createErrors.Add(name, 1)
+	}
+}
+
+// Gets returns number of recorded lookups.
+func (e *Expvar) Gets() int64 {
+	return e.gets.Value()
+}
+
+// Hits returns number of recorded successful lookups.
+func (e *Expvar) Hits() int64 {
+	return e.hits.Value()
+}
+
+// Misses returns number of recorded failed lookups.
+func (e *Expvar) Misses() int64 {
+	return e.misses.Value()
+}
+
+// Adds returns number of recorded additions.
+func (e *Expvar) Adds() int64 {
+	return e.adds.Value()
+}
+
+// Sets returns number of recorded settings.
+func (e *Expvar) Sets() int64 {
+	return e.sets.Value()
+}
+
+// Removes returns number of recorded removals.
+func (e *Expvar) Removes() int64 {
+	return e.removes.Value()
+}
+
+// Creates returns number of recorded creations of object with a given name.
+func (e *Expvar) Creates(name string) int64 {
+	return mapInt(e.creates, name)
+}
+
+// CreateErrors returns number of recorded failed creations of object with a given name.
+func (e *Expvar) CreateErrors(name string) int64 {
+	return mapInt(e.createErrors, name)
+}
+
+// CreateDuration returns total recorded duration of creations of object with a given name.
+func (e *Expvar) CreateDuration(name string) time.Duration {
+	if value, ok := e.createSeconds.Get(name).(*expvar.Float); ok {
+		return time.Duration(value.Value() * float64(time.Second))
+	}
+
+	return 0
+}
+
+func getInt(root *expvar.Map, key string) *expvar.Int {
+	if value, ok := root.Get(key).(*expvar.Int); ok {
+		return value
+	}
+
+	value := new(expvar.Int)
+	root.Set(key, value)
+
+	return value
+}
+
+func getMap(root *expvar.Map, key string) *expvar.Map {
+	if value, ok := root.Get(key).(*expvar.Map); ok {
+		return value
+	}
+
+	value := new(expvar.Map).Init()
+	root.Set(key, value)
+
+	return value
+}
+
+func mapInt(root *expvar.Map, key string) int64 {
+	if value, ok := root.Get(key).(*expvar.Int); ok {
+		return value.Value()
+	}
+
+	return 0
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/metrics"
+)
+
+func TestExpvarCounters(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	recorder.Get("object", true)
+	recorder.Get("object", false)
+	recorder.Get("object", true)
+	recorder.Add("object")
+	recorder.Set("object")
+	recorder.Set("object")
+	recorder.Remove("object")
+
+	assert.Equal(test, int64(3), recorder.Gets())
+	assert.Equal(test, int64(2), recorder.Hits())
+	assert.Equal(test, int64(1), recorder.Misses())
+	assert.Equal(test, int64(1), recorder.Adds())
+	assert.Equal(test, int64(2), recorder.Sets())
+	assert.Equal(test, int64(1), recorder.Removes())
+}
+
+func TestExpvarCreate(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	recorder.Create("object", time.Second, nil)
+	recorder.Create("object", time.Second, errors.New("error"))
+
+	assert.Equal(test, int64(2), recorder.Creates("object"))
+	assert.Equal(test, int64(1), recorder.CreateErrors("object"))
+	assert.Equal(test, 2*time.Second, recorder.CreateDuration("object"))
+	assert.Zero(test, recorder.Creates("unknown"))
+	assert.Zero(test, recorder.CreateDuration("unknown"))
+}
+
+func TestExpvarShared(test *testing.T) {
+	adds := metrics.NewExpvar("test_expvar_shared").Adds()
+
+	metrics.NewExpvar("test_expvar_shared").Add("object")
+	metrics.NewExpvar("test_expvar_shared").Add("object")
+
+	assert.Equal(test, adds+2, metrics.NewExpvar("test_expvar_shared").Adds())
+	assert.Contains(test, expvar.Get("test_expvar_shared").String(), fmt.Sprintf(`"adds": %d`, adds+2))
+}
+
+func TestExpvarConcurrent(test *testing.T) {
+	var group sync.WaitGroup
+
+	recorders := make([]*metrics.Expvar, 8)
+
+	for i := range recorders {
+		group.Add(1)
+
+		go func(i int) {
+			defer group.Done()
+			recorders[i] = metrics.NewExpvar("test_expvar_concurrent")
+		}(i)
+	}
+
+	group.Wait()
+
+	adds := recorders[0].Adds()
+
+	for _, recorder := range recorders {
+		recorder.Add("object")
+	}
+
+	assert.Equal(test, adds+int64(len(recorders)), recorders[0].Adds())
+}
+
+func TestExpvarLocal(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	recorder.Add("object")
+
+	assert.Equal(test, int64(1), recorder.Adds())
+	assert.Zero(test, metrics.NewLocalExpvar().Adds())
+}
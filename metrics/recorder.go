@@ -0,0 +1,56 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+)
+
+// Recorder defines a metrics recorder. Implementations must be safe for
+// concurrent use because lookups are recorded under a read lock.
+type Recorder interface {
+	// Get records a lookup of object with a given name.
+	Get(name string, hit bool)
+
+	// Add records an addition of object with a given name.
+	Add(name string)
+
+	// Set records a setting of object with a given name.
+	Set(name string)
+
+	// Remove records a removal of object with a given name.
+	Remove(name string)
+
+	// Create records a creation of object with a given name.
+	Create(name string, duration time.Duration, err error)
+}
+
+// Nop defines a metrics recorder that records nothing.
+type Nop struct{}
+
+// Get records nothing.
+func (Nop) Get(string, bool) {}
+
+// Add records nothing.
+func (Nop) Add(string) {}
+
+// Set records nothing.
+func (Nop) Set(string) {}
+
+// Remove records nothing.
+func (Nop) Remove(string) {}
+
+// Create records nothing.
+func (Nop) Create(string, time.Duration, error) {}
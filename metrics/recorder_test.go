@@ -0,0 +1,35 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/metrics"
+)
+
+func TestRecorderNop(test *testing.T) {
+	var recorder metrics.Recorder = metrics.Nop{}
+
+	assert.NotPanics(test, func() {
+		recorder.Get("object", true)
+		recorder.Add("object")
+		recorder.Set("object")
+		recorder.Remove("object")
+		recorder.Create("object", time.Second, nil)
+	})
+}
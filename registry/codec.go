@@ -0,0 +1,155 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Codec defines an encoding format used to encode and decode registry objects.
+type Codec interface {
+	// Encode writes encoded objects to a given writer.
+	Encode(writer io.Writer, objects Objects) error
+
+	// Decode reads and decodes objects from a given reader.
+	Decode(reader io.Reader) (Objects, error)
+}
+
+// JSON defines a codec that encodes objects as a JSON object. Objects of types
+// registered with RegisterType are encoded together with their type names and
+// decoded back to the same types. Other objects are decoded as generic JSON
+// values like map[string]interface{}, []interface{}, string or float64.
+type JSON struct{}
+
+// Gob defines a codec that encodes objects with the gob package. Objects of
+// non-basic types must be registered with RegisterType.
+type Gob struct{}
+
+// jsonObject defines an encoded JSON object with an optional type name.
+type jsonObject struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Encode writes encoded objects to a given writer.
+func (JSON) Encode(writer io.Writer, objects Objects) error {
+	encoded := make(map[string]jsonObject, len(objects))
+
+	for name, object := range objects {
+		value, err := json.Marshal(object)
+
+		if err != nil {
+			return rterror.New("cannot encode object", name, err)
+		}
+
+		kind, _ := typeName(reflect.TypeOf(object))
+
+		encoded[name] = jsonObject{
+			Type:  kind,
+			Value: value,
+		}
+	}
+
+	if err := json.NewEncoder(writer).Encode(encoded); err != nil {
+		return rterror.New("cannot encode objects", err)
+	}
+
+	return nil
+}
+
+// Decode reads and decodes objects from a given reader.
+func (JSON) Decode(reader io.Reader) (Objects, error) {
+	encoded := map[string]jsonObject{}
+
+	if err := json.NewDecoder(reader).Decode(&encoded); err != nil {
+		return nil, rterror.New("cannot decode objects", err)
+	}
+
+	objects := make(Objects, len(encoded))
+
+	for name, object := range encoded {
+		value, err := decodeJSON(object)
+
+		if err != nil {
+			return nil, rterror.New("cannot decode object", name, err)
+		}
+
+		objects[name] = value
+	}
+
+	return objects, nil
+}
+
+// Encode writes encoded objects to a given writer.
+func (Gob) Encode(writer io.Writer, objects Objects) error {
+	if err := gob.NewEncoder(writer).Encode(map[string]interface{}(objects)); err != nil {
+		return rterror.New("cannot encode objects", err)
+	}
+
+	return nil
+}
+
+// Decode reads and decodes objects from a given reader.
+func (Gob) Decode(reader io.Reader) (Objects, error) {
+	objects := Objects{}
+
+	if err := gob.NewDecoder(reader).Decode((*map[string]interface{})(&objects)); err != nil {
+		return nil, rterror.New("cannot decode objects", err)
+	}
+
+	return objects, nil
+}
+
+// decodeJSON returns decoded value of a given encoded JSON object.
+func decodeJSON(object jsonObject) (interface{}, error) {
+	if object.Type == "" {
+		var value interface{}
+
+		if err := json.Unmarshal(object.Value, &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	kind, ok := typeOf(object.Type)
+
+	if !ok {
+		return nil, rterror.New("type was not registered", object.Type)
+	}
+
+	pointer := kind.Kind() == reflect.Ptr
+
+	if pointer {
+		kind = kind.Elem()
+	}
+
+	value := reflect.New(kind)
+
+	if err := json.Unmarshal(object.Value, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	if pointer {
+		return value.Interface(), nil
+	}
+
+	return value.Elem().Interface(), nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type CodecConfig struct {
+	Name    string
+	Timeout int
+}
+
+type CodecPointer struct {
+	Enabled bool
+}
+
+type CodecUnregistered struct {
+	Name string
+}
+
+func init() { // nolint: gochecknoinits
+	if err := registry.RegisterType("codec.config", CodecConfig{}); err != nil {
+		panic(err)
+	}
+
+	if err := registry.RegisterType("codec.pointer", &CodecPointer{}); err != nil {
+		panic(err)
+	}
+}
+
+func TestCodecJSON(test *testing.T) {
+	var buffer bytes.Buffer
+
+	objects := registry.Objects{
+		"config":  CodecConfig{Name: "name", Timeout: 5},
+		"pointer": &CodecPointer{Enabled: true},
+		"string":  "value",
+		"number":  5.0,
+		"list":    []interface{}{"a", true},
+	}
+
+	assert.NoError(test, registry.JSON{}.Encode(&buffer, objects))
+	assert.Contains(test, buffer.String(), `"type":"codec.config"`)
+
+	decoded, err := registry.JSON{}.Decode(&buffer)
+
+	assert.NoError(test, err)
+	assert.Equal(test, objects, decoded)
+}
+
+func TestCodecJSONUnregistered(test *testing.T) {
+	var buffer bytes.Buffer
+
+	assert.NoError(test, registry.JSON{}.Encode(&buffer, registry.Objects{
+		"object": CodecUnregistered{Name: "name"},
+	}))
+
+	decoded, err := registry.JSON{}.Decode(&buffer)
+
+	assert.NoError(test, err)
+	assert.Equal(test, map[string]interface{}{"Name": "name"}, decoded["object"])
+}
+
+func TestCodecJSONEncodeError(test *testing.T) {
+	var buffer bytes.Buffer
+
+	assert.Error(test, registry.JSON{}.Encode(&buffer, registry.Objects{
+		"object": make(chan int),
+	}))
+}
+
+func TestCodecJSONDecodeError(test *testing.T) {
+	_, err := registry.JSON{}.Decode(strings.NewReader(`{`))
+	assert.Error(test, err)
+
+	_, err = registry.JSON{}.Decode(strings.NewReader(`{"object":{"type":"codec.unknown","value":5}}`))
+	assert.Error(test, err)
+
+	_, err = registry.JSON{}.Decode(strings.NewReader(`{"object":{"type":"codec.config","value":5}}`))
+	assert.Error(test, err)
+}
+
+func TestCodecGob(test *testing.T) {
+	var buffer bytes.Buffer
+
+	objects := registry.Objects{
+		"config":  CodecConfig{Name: "name", Timeout: 5},
+		"pointer": &CodecPointer{Enabled: true},
+		"string":  "value",
+		"number":  5,
+	}
+
+	assert.NoError(test, registry.Gob{}.Encode(&buffer, objects))
+
+	decoded, err := registry.Gob{}.Decode(&buffer)
+
+	assert.NoError(test, err)
+	assert.Equal(test, objects, decoded)
+}
+
+func TestCodecGobError(test *testing.T) {
+	var buffer bytes.Buffer
+
+	assert.Error(test, registry.Gob{}.Encode(&buffer, registry.Objects{
+		"object": CodecUnregistered{},
+	}))
+
+	_, err := registry.Gob{}.Decode(strings.NewReader("invalid"))
+	assert.Error(test, err)
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// overlayKey defines a context key used to store registry overlays.
+type overlayKey struct{}
+
+// overlay defines objects overriding global registry objects in a context.
+type overlay struct {
+	parent  *overlay
+	objects Objects
+}
+
+// Lookup defines a read-only view of the global registry with objects
+// overridden by context overlays. Overlays are consulted first, the most
+// recently added one first, and then the global registry. Objects found in
+// overlays are returned without calling global registry interceptors.
+type Lookup struct {
+	overlay *overlay
+}
+
+// WithOverlay returns a copy of parent context with given objects overriding
+// global registry objects for lookups done through that context. Global
+// registry is not modified. It panics if any name or object does not pass
+// global registry constraints.
+func WithOverlay(ctx context.Context, objects Objects) context.Context {
+	o := &overlay{
+		objects: make(Objects, len(objects)),
+	}
+
+	o.parent, _ = ctx.Value(overlayKey{}).(*overlay)
+
+	getDefault().guard.Read(func() {
+		for name, object := range objects {
+			key, err := getInstance().Key(name)
+
+			if err != nil {
+				panic(err)
+			}
+
+			if err = getInstance().Validate(key, object); err != nil {
+				panic(err)
+			}
+
+			o.objects[key] = object
+		}
+	})
+
+	return context.WithValue(ctx, overlayKey{}, o)
+}
+
+// FromContext returns a read-only view of the global registry with objects
+// overridden by overlays stored in a given context.
+func FromContext(ctx context.Context) Lookup {
+	o, _ := ctx.Value(overlayKey{}).(*overlay)
+
+	return Lookup{
+		overlay: o,
+	}
+}
+
+// Get returns registered object by given name.
+func (l Lookup) Get(name string) (object interface{}, err error) {
+	getDefault().guard.Read(func() {
+		object, err = l.get(name)
+	})
+
+	return object, err
+}
+
+// Gets returns registered objects by given names.
+func (l Lookup) Gets(names []string) (objects Objects, err error) {
+	errs := make([]interface{}, 0, len(names))
+
+	objects = Objects{}
+
+	getDefault().guard.Read(func() {
+		for _, name := range names {
+			object, getErr := l.get(name)
+
+			if getErr != nil {
+				errs = append(errs, getErr)
+				continue
+			}
+
+			objects[name] = object
+		}
+	})
+
+	if len(errs) != 0 {
+		return objects, rterror.New("cannot get objects", errs...)
+	}
+
+	return objects, nil
+}
+
+// GetAll returns all registered objects including overridden ones.
+func (l Lookup) GetAll() (objects Objects) {
+	getDefault().guard.Read(func() {
+		objects = getInstance().GetAll()
+	})
+
+	overlays := []*overlay{}
+
+	for o := l.overlay; o != nil; o = o.parent {
+		overlays = append(overlays, o)
+	}
+
+	for i := len(overlays) - 1; i >= 0; i-- {
+		for name, object := range overlays[i].objects {
+			objects[name] = object
+		}
+	}
+
+	return objects
+}
+
+// IsExist returns true if object with given name was registered, otherwise it returns false.
+func (l Lookup) IsExist(name string) (value bool) {
+	getDefault().guard.Read(func() {
+		_, value = l.find(name)
+
+		if !value {
+			value = getInstance().IsExist(name)
+		}
+	})
+
+	return value
+}
+
+// get returns registered object by given name. Global registry must be locked.
+func (l Lookup) get(name string) (interface{}, error) {
+	if object, ok := l.find(name); ok {
+		return object, nil
+	}
+
+	return getInstance().Get(name)
+}
+
+// find returns object with given name found in overlays. Global registry must be locked.
+func (l Lookup) find(name string) (interface{}, bool) {
+	if l.overlay == nil {
+		return nil, false
+	}
+
+	key, err := getInstance().Key(name)
+
+	if err != nil {
+		return nil, false
+	}
+
+	for o := l.overlay; o != nil; o = o.parent {
+		if object, ok := o.objects[key]; ok {
+			return object, true
+		}
+	}
+
+	return nil, false
+}
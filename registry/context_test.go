@@ -0,0 +1,133 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestContextWithOverlay(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Sets(registry.Objects{
+		"objectA": "global",
+		"objectB": "global",
+	})
+
+	ctx := registry.WithOverlay(context.Background(), registry.Objects{
+		"objectA": "overlay",
+		"objectC": "overlay",
+	})
+
+	lookup := registry.FromContext(ctx)
+
+	object, err := lookup.Get("objectA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "overlay", object)
+
+	object, err = lookup.Get("objectB")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+
+	_, err = lookup.Get("objectD")
+	assert.Error(test, err)
+
+	assert.True(test, lookup.IsExist("objectC"))
+	assert.True(test, lookup.IsExist("objectB"))
+	assert.False(test, lookup.IsExist("objectD"))
+	assert.False(test, registry.IsExist("objectC"))
+
+	object, err = registry.Get("objectA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+}
+
+func TestContextNestedOverlay(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Set("object", "global")
+
+	parent := registry.WithOverlay(context.Background(), registry.Objects{
+		"object": "parent",
+		"tenant": "parent",
+	})
+
+	child := registry.WithOverlay(parent, registry.Objects{
+		"object": "child",
+	})
+
+	assert.Equal(test, registry.Objects{
+		"object": "child",
+		"tenant": "parent",
+	}, registry.FromContext(child).GetAll())
+
+	assert.Equal(test, registry.Objects{
+		"object": "parent",
+		"tenant": "parent",
+	}, registry.FromContext(parent).GetAll())
+
+	assert.Equal(test, registry.Objects{
+		"object": "global",
+	}, registry.FromContext(context.Background()).GetAll())
+}
+
+func TestContextGets(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Set("objectA", "global")
+
+	ctx := registry.WithOverlay(context.Background(), registry.Objects{
+		"objectB": "overlay",
+	})
+
+	objects, err := registry.FromContext(ctx).Gets([]string{"objectA", "objectB"})
+
+	assert.NoError(test, err)
+	assert.Equal(test, registry.Objects{"objectA": "global", "objectB": "overlay"}, objects)
+
+	objects, err = registry.FromContext(ctx).Gets([]string{"objectB", "objectC"})
+
+	assert.Error(test, err)
+	assert.Equal(test, registry.Objects{"objectB": "overlay"}, objects)
+}
+
+func TestContextOverlayConstraints(test *testing.T) {
+	defer registry.RemoveAll()
+	defer registry.Configure(registry.WithNormalizer(nil), registry.WithNameValidator(nil))
+
+	registry.Configure(
+		registry.WithNormalizer(registry.FoldCase),
+		registry.WithNameValidator(registry.Reserved("default")),
+	)
+
+	assert.Panics(test, func() {
+		registry.WithOverlay(context.Background(), registry.Objects{"default": "overlay"})
+	})
+
+	ctx := registry.WithOverlay(context.Background(), registry.Objects{"Object": "overlay"})
+
+	object, err := registry.FromContext(ctx).Get("OBJECT")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "overlay", object)
+	assert.False(test, registry.FromContext(ctx).IsExist("default"))
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// FileMode defines file permissions used when writing registry files.
+const FileMode = 0o600
+
+// Encode writes all registered objects encoded with a given codec.
+func (r *Registry) Encode(writer io.Writer, codec Codec) error {
+	return codec.Encode(writer, r.objects)
+}
+
+// Decode reads objects encoded with a given codec and sets them to registry.
+// It returns an error without setting anything if objects cannot be decoded
+// or any name or object does not pass registry constraints.
+func (r *Registry) Decode(reader io.Reader, codec Codec) error {
+	objects, err := codec.Decode(reader)
+
+	if err != nil {
+		return err
+	}
+
+	return r.setObjects(objects)
+}
+
+// ReadFile reads objects from a file encoded with a given codec and sets them to registry.
+func (r *Registry) ReadFile(path string, codec Codec) error {
+	file, err := os.Open(filepath.Clean(path))
+
+	if err != nil {
+		return rterror.New("cannot open file", path, err)
+	}
+
+	defer file.Close()
+
+	return r.Decode(file, codec)
+}
+
+// WriteFile writes all registered objects encoded with a given codec to a file.
+// The file is replaced atomically, it is never left partially written.
+func (r *Registry) WriteFile(path string, codec Codec) error {
+	var buffer bytes.Buffer
+
+	if err := r.Encode(&buffer, codec); err != nil {
+		return err
+	}
+
+	return writeFile(path, buffer.Bytes())
+}
+
+// MarshalJSON returns all registered objects encoded with the JSON codec.
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := r.Encode(&buffer, JSON{}); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buffer.Bytes()), nil
+}
+
+// UnmarshalJSON sets objects decoded with the JSON codec to registry.
+func (r *Registry) UnmarshalJSON(data []byte) error {
+	return r.initialize().Decode(bytes.NewReader(data), JSON{})
+}
+
+// GobEncode returns all registered objects encoded with the Gob codec.
+func (r *Registry) GobEncode() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := r.Encode(&buffer, Gob{}); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// GobDecode sets objects decoded with the Gob codec to registry.
+func (r *Registry) GobDecode(data []byte) error {
+	return r.initialize().Decode(bytes.NewReader(data), Gob{})
+}
+
+// initialize initializes zero value registry object, for example created by
+// a decoder, to the same state as created by the New function.
+func (r *Registry) initialize() *Registry {
+	if r.objects == nil {
+		*r = *New()
+	}
+
+	return r
+}
+
+// writeFile writes data to a temporary file and renames it to a given path.
+func writeFile(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+
+	if err != nil {
+		return rterror.New("cannot create file", path, err)
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return rterror.New("cannot write file", path, err)
+	}
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return rterror.New("cannot sync file", path, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return rterror.New("cannot close file", path, err)
+	}
+
+	if err = os.Chmod(file.Name(), FileMode); err != nil {
+		return rterror.New("cannot change file mode", path, err)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return rterror.New("cannot rename file", path, err)
+	}
+
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes a given directory to disk, making renames of files in it
+// durable. Directories cannot be synced on Windows, so it does nothing there.
+func syncDir(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	dir, err := os.Open(path)
+
+	if err != nil {
+		return rterror.New("cannot open directory", path, err)
+	}
+
+	defer dir.Close()
+
+	if err = dir.Sync(); err != nil {
+		return rterror.New("cannot sync directory", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestEncodingEncodeDecode(test *testing.T) {
+	var buffer bytes.Buffer
+
+	r := registry.New().Sets(registry.Objects{
+		"config": CodecConfig{Name: "name"},
+		"string": "value",
+	})
+
+	assert.NoError(test, r.Encode(&buffer, registry.JSON{}))
+
+	decoded := registry.New()
+
+	assert.NoError(test, decoded.Decode(&buffer, registry.JSON{}))
+	assert.Equal(test, r.GetAll(), decoded.GetAll())
+}
+
+func TestEncodingDecodeConstraints(test *testing.T) {
+	var buffer bytes.Buffer
+
+	assert.NoError(test, registry.New().Sets(registry.Objects{
+		"config": CodecConfig{Name: "name"},
+		"string": "value",
+	}).Encode(&buffer, registry.JSON{}))
+
+	r := registry.NewTyped(reflect.TypeOf(CodecConfig{}))
+
+	assert.Error(test, r.Decode(&buffer, registry.JSON{}))
+	assert.True(test, r.IsEmpty())
+}
+
+func TestEncodingFile(test *testing.T) {
+	path := filepath.Join(TempDir(test), "registry.json")
+
+	r := registry.New().Sets(registry.Objects{
+		"config":  CodecConfig{Name: "name"},
+		"pointer": &CodecPointer{Enabled: true},
+	})
+
+	assert.NoError(test, r.WriteFile(path, registry.JSON{}))
+
+	loaded := registry.New()
+
+	assert.NoError(test, loaded.ReadFile(path, registry.JSON{}))
+	assert.Equal(test, r.GetAll(), loaded.GetAll())
+	assert.Error(test, loaded.ReadFile(filepath.Join(TempDir(test), "missing.json"), registry.JSON{}))
+	assert.Error(test, r.WriteFile(filepath.Join(path, "invalid"), registry.JSON{}))
+}
+
+func TestEncodingJSONMarshaler(test *testing.T) {
+	r := registry.New().Set("config", CodecConfig{Name: "name"})
+
+	data, err := json.Marshal(r)
+
+	assert.NoError(test, err)
+
+	var decoded registry.Registry
+
+	assert.NoError(test, json.Unmarshal(data, &decoded))
+	assert.Equal(test, r.GetAll(), decoded.GetAll())
+}
+
+func TestEncodingGobEncoder(test *testing.T) {
+	var buffer bytes.Buffer
+
+	r := registry.New().Set("config", CodecConfig{Name: "name"})
+
+	assert.NoError(test, gob.NewEncoder(&buffer).Encode(r))
+
+	decoded := registry.New()
+
+	assert.NoError(test, gob.NewDecoder(&buffer).Decode(decoded))
+	assert.Equal(test, r.GetAll(), decoded.GetAll())
+}
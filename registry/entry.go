@@ -0,0 +1,88 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modulePath defines import path of this module used to skip internal callers.
+const modulePath = "gitlab.com/tymonx/go-patterns/"
+
+// maxCallers defines maximum number of callers inspected to find a call site.
+const maxCallers = 32
+
+// Entry defines a registered object with its registration details.
+type Entry struct {
+	Name   string
+	Object interface{}
+	Source string
+}
+
+// Entries returns all registered objects with their registration details
+// sorted by name.
+func (r *Registry) Entries() []Entry {
+	entries := make([]Entry, 0, len(r.objects))
+
+	for name, object := range r.objects {
+		entries = append(entries, Entry{
+			Name:   name,
+			Object: object,
+			Source: r.sources[name],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+// callSite returns file and line of the first caller outside of this module.
+func callSite() string {
+	pcs := make([]uintptr, maxCallers)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+
+		if !isInternal(frame.Function) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isInternal returns true if a given function belongs to non-test package of this module.
+func isInternal(function string) bool {
+	if !strings.HasPrefix(function, modulePath) {
+		return false
+	}
+
+	name := function[len(modulePath):]
+
+	if index := strings.Index(name, "."); index >= 0 {
+		name = name[:index]
+	}
+
+	return !strings.HasSuffix(name, "_test")
+}
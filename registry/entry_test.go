@@ -0,0 +1,53 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestEntries(test *testing.T) {
+	r := registry.New()
+
+	assert.NoError(test, r.Add("objectB", "valueB"))
+	r.Set("objectA", "valueA")
+
+	entries := r.Entries()
+
+	assert.Len(test, entries, 2)
+	assert.Equal(test, "objectA", entries[0].Name)
+	assert.Equal(test, "valueA", entries[0].Object)
+	assert.Contains(test, entries[0].Source, "entry_test.go:")
+	assert.Equal(test, "objectB", entries[1].Name)
+	assert.Equal(test, "valueB", entries[1].Object)
+	assert.Contains(test, entries[1].Source, "entry_test.go:")
+}
+
+func TestEntriesRemove(test *testing.T) {
+	r := registry.New()
+
+	r.Sets(registry.Objects{
+		"objectA": "valueA",
+		"objectB": "valueB",
+	})
+
+	r.Remove("objectA")
+
+	assert.Len(test, r.Entries(), 1)
+	assert.Empty(test, r.RemoveAll().Entries())
+}
@@ -0,0 +1,298 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"io"
+	"sort"
+
+	"gitlab.com/tymonx/go-patterns/guard"
+)
+
+// DefaultGlobal defines the name of the default global registry used by
+// package-level functions.
+const DefaultGlobal = "default"
+
+var gGlobals map[string]*Global // nolint: gochecknoglobals
+var gGlobalsGuard guard.Guard   // nolint: gochecknoglobals
+
+// Global defines a named process-wide registry instance safe for concurrent use.
+// Each global registry has its own guard and objects, so libraries using
+// different global registries never collide on object names.
+type Global struct {
+	name     string
+	guard    guard.Guard
+	instance *Registry
+}
+
+// Named returns a global registry with a given name. It is created on first use.
+func Named(name string) (global *Global) {
+	gGlobalsGuard.Read(func() {
+		global = gGlobals[name]
+	})
+
+	if global != nil {
+		return global
+	}
+
+	gGlobalsGuard.Write(func() {
+		if gGlobals == nil {
+			gGlobals = make(map[string]*Global)
+		}
+
+		if global = gGlobals[name]; global == nil {
+			global = &Global{
+				name:     name,
+				instance: New(),
+			}
+
+			gGlobals[name] = global
+		}
+	})
+
+	return global
+}
+
+// Globals returns sorted names of all created global registries.
+func Globals() (names []string) {
+	gGlobalsGuard.Read(func() {
+		names = make([]string, 0, len(gGlobals))
+
+		for name := range gGlobals {
+			names = append(names, name)
+		}
+	})
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Name returns global registry name.
+func (g *Global) Name() string {
+	return g.name
+}
+
+// Configure applies given options to registry.
+func (g *Global) Configure(options ...Option) {
+	g.guard.Write(func() {
+		g.instance.Configure(options...)
+	})
+}
+
+// Add adds a new object with a given unique id to registry.
+func (g *Global) Add(name string, object interface{}) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.Add(name, object)
+	})
+
+	return err
+}
+
+// Adds adds new objects with given unique ids to registry.
+func (g *Global) Adds(objects Objects) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.Adds(objects)
+	})
+
+	return err
+}
+
+// Set sets an object with a given unique id to registry.
+// It panics if name or object does not pass registry constraints.
+func (g *Global) Set(name string, object interface{}) {
+	g.guard.Write(func() {
+		g.instance.Set(name, object)
+	})
+}
+
+// Sets sets objects with given unique ids to registry.
+// It panics without setting anything if any name or object does not pass registry constraints.
+func (g *Global) Sets(objects Objects) {
+	g.guard.Write(func() {
+		g.instance.Sets(objects)
+	})
+}
+
+// TrySet sets an object with a given unique id to registry. It returns an
+// error if name or object does not pass registry constraints.
+func (g *Global) TrySet(name string, object interface{}) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.TrySet(name, object)
+	})
+
+	return err
+}
+
+// TrySets sets objects with given unique ids to registry. It returns an error
+// without setting anything if any name or object does not pass registry constraints.
+func (g *Global) TrySets(objects Objects) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.TrySets(objects)
+	})
+
+	return err
+}
+
+// Use appends given interceptors to registry lookup interceptor chain.
+func (g *Global) Use(interceptors ...Interceptor) {
+	g.guard.Write(func() {
+		g.instance.Use(interceptors...)
+	})
+}
+
+// Get returns registered object by given name.
+func (g *Global) Get(name string) (object interface{}, err error) {
+	g.guard.Read(func() {
+		object, err = g.instance.Get(name)
+	})
+
+	return object, err
+}
+
+// Gets returns registered objects by given names.
+func (g *Global) Gets(names []string) (objects Objects, err error) {
+	g.guard.Read(func() {
+		objects, err = g.instance.Gets(names)
+	})
+
+	return objects, err
+}
+
+// GetAll returns all registered objects.
+func (g *Global) GetAll() (objects Objects) {
+	g.guard.Read(func() {
+		objects = g.instance.GetAll()
+	})
+
+	return objects
+}
+
+// Entries returns all registered objects with their registration details sorted by name.
+func (g *Global) Entries() (entries []Entry) {
+	g.guard.Read(func() {
+		entries = g.instance.Entries()
+	})
+
+	return entries
+}
+
+// Encode writes all registered objects encoded with a given codec.
+func (g *Global) Encode(writer io.Writer, codec Codec) (err error) {
+	g.guard.Read(func() {
+		err = g.instance.Encode(writer, codec)
+	})
+
+	return err
+}
+
+// Decode reads objects encoded with a given codec and sets them to registry.
+func (g *Global) Decode(reader io.Reader, codec Codec) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.Decode(reader, codec)
+	})
+
+	return err
+}
+
+// ReadFile reads objects from a file encoded with a given codec and sets them to registry.
+func (g *Global) ReadFile(path string, codec Codec) (err error) {
+	g.guard.Write(func() {
+		err = g.instance.ReadFile(path, codec)
+	})
+
+	return err
+}
+
+// WriteFile writes all registered objects encoded with a given codec to a file.
+func (g *Global) WriteFile(path string, codec Codec) (err error) {
+	g.guard.Read(func() {
+		err = g.instance.WriteFile(path, codec)
+	})
+
+	return err
+}
+
+// Remove removes registered object.
+func (g *Global) Remove(name string) {
+	g.guard.Write(func() {
+		g.instance.Remove(name)
+	})
+}
+
+// Removes removes registered object.
+func (g *Global) Removes(names []string) {
+	g.guard.Write(func() {
+		g.instance.Removes(names)
+	})
+}
+
+// RemoveAll removes all registered object.
+func (g *Global) RemoveAll() {
+	g.guard.Write(func() {
+		g.instance.RemoveAll()
+	})
+}
+
+// IsExist returns true if object with given name was registered, otherwise it returns false.
+func (g *Global) IsExist(name string) (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsExist(name)
+	})
+
+	return value
+}
+
+// IsExists returns true if all objects with given names were registered, otherwise it returns false.
+func (g *Global) IsExists(names []string) (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsExists(names)
+	})
+
+	return value
+}
+
+// IsEmpty returns true if there are no registered objects, otherwise it returns false.
+func (g *Global) IsEmpty() (value bool) {
+	g.guard.Read(func() {
+		value = g.instance.IsEmpty()
+	})
+
+	return value
+}
+
+// Size returns number of registered objects.
+func (g *Global) Size() (value int) {
+	g.guard.Read(func() {
+		value = g.instance.Size()
+	})
+
+	return value
+}
+
+// Replace replaces global registry instance with a given one and returns
+// the previous instance. A nil instance is replaced with a new empty registry.
+func (g *Global) Replace(instance *Registry) (previous *Registry) {
+	if instance == nil {
+		instance = New()
+	}
+
+	g.guard.Write(func() {
+		previous = g.instance
+		g.instance = instance
+	})
+
+	return previous
+}
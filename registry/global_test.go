@@ -0,0 +1,142 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestGlobalNamed(test *testing.T) {
+	codecs := registry.Named("codecs")
+	drivers := registry.Named("drivers")
+
+	defer codecs.RemoveAll()
+	defer drivers.RemoveAll()
+
+	assert.Same(test, codecs, registry.Named("codecs"))
+	assert.Equal(test, "codecs", codecs.Name())
+
+	codecs.Set("default", "json")
+	drivers.Set("default", "sqlite")
+
+	object, err := codecs.Get("default")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "json", object)
+
+	object, err = drivers.Get("default")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "sqlite", object)
+
+	assert.False(test, registry.IsExist("default"))
+}
+
+func TestGlobalDefault(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Set("object", "value")
+
+	global := registry.Named(registry.DefaultGlobal)
+
+	object, err := global.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", object)
+	assert.Equal(test, registry.Objects{"object": "value"}, global.GetAll())
+}
+
+func TestGlobalGlobals(test *testing.T) {
+	registry.Named("globals-b")
+	registry.Named("globals-a")
+
+	names := registry.Globals()
+
+	assert.Subset(test, names, []string{"globals-a", "globals-b"})
+	assert.True(test, sort.StringsAreSorted(names))
+}
+
+func TestGlobalConcurrentNamed(test *testing.T) {
+	var wait sync.WaitGroup
+
+	globals := make([]*registry.Global, 8)
+
+	for i := range globals {
+		wait.Add(1)
+
+		go func(index int) {
+			defer wait.Done()
+			globals[index] = registry.Named("concurrent")
+		}(i)
+	}
+
+	wait.Wait()
+
+	for _, global := range globals {
+		assert.Same(test, globals[0], global)
+	}
+}
+
+func TestGlobalMethods(test *testing.T) {
+	global := registry.Named("methods")
+
+	defer global.RemoveAll()
+
+	global.Configure(registry.WithNormalizer(registry.FoldCase))
+
+	assert.NoError(test, global.Add("ObjectA", 1))
+	assert.Error(test, global.Add("objecta", 1))
+	assert.NoError(test, global.Adds(registry.Objects{"objectB": 2}))
+
+	global.Sets(registry.Objects{"objectC": 3})
+
+	objects, err := global.Gets([]string{"objecta", "objectb"})
+
+	assert.NoError(test, err)
+	assert.Len(test, objects, 2)
+	assert.Len(test, global.Entries(), 3)
+	assert.True(test, global.IsExists([]string{"OBJECTA", "OBJECTC"}))
+	assert.Equal(test, 3, global.Size())
+
+	var buffer bytes.Buffer
+
+	assert.NoError(test, global.Encode(&buffer, registry.JSON{}))
+
+	path := filepath.Join(TempDir(test), "registry.json")
+
+	assert.NoError(test, global.WriteFile(path, registry.JSON{}))
+
+	global.Removes([]string{"objecta", "objectb", "objectc"})
+	assert.True(test, global.IsEmpty())
+
+	assert.NoError(test, global.Decode(&buffer, registry.JSON{}))
+	assert.Equal(test, 3, global.Size())
+
+	global.RemoveAll()
+
+	assert.NoError(test, global.ReadFile(path, registry.JSON{}))
+	assert.Equal(test, 3, global.Size())
+
+	previous := global.Replace(nil)
+	assert.True(test, global.IsEmpty())
+	assert.Equal(test, 3, previous.Size())
+}
@@ -15,136 +15,149 @@
 package registry
 
 import (
+	"io"
 	"sync"
-
-	"gitlab.com/tymonx/go-patterns/guard"
 )
 
-var gInstance *Registry // nolint: gochecknoglobals
-var gGuard guard.Guard  // nolint: gochecknoglobals
-var gOnce sync.Once     // nolint: gochecknoglobals
+var gDefault *Global // nolint: gochecknoglobals
+var gOnce sync.Once  // nolint: gochecknoglobals
 
-// Add adds a new object with a given unique id to registry.
-func Add(name string, object interface{}) (err error) {
-	gGuard.Write(func() {
-		err = getInstance().Add(name, object)
-	})
+// Configure applies given options to registry.
+func Configure(options ...Option) {
+	getDefault().Configure(options...)
+}
 
-	return err
+// Add adds a new object with a given unique id to registry.
+func Add(name string, object interface{}) error {
+	return getDefault().Add(name, object)
 }
 
 // Adds adds new objects with given unique ids to registry.
-func Adds(objects Objects) (err error) {
-	gGuard.Write(func() {
-		err = getInstance().Adds(objects)
-	})
-
-	return err
+func Adds(objects Objects) error {
+	return getDefault().Adds(objects)
 }
 
 // Set sets an object with a given unique id to registry.
+// It panics if name or object does not pass registry constraints.
 func Set(name string, object interface{}) {
-	gGuard.Write(func() {
-		getInstance().Set(name, object)
-	})
+	getDefault().Set(name, object)
 }
 
 // Sets sets objects with given unique ids to registry.
+// It panics without setting anything if any name or object does not pass registry constraints.
 func Sets(objects Objects) {
-	gGuard.Write(func() {
-		getInstance().Sets(objects)
-	})
+	getDefault().Sets(objects)
 }
 
-// Get returns registered object by given name.
-func Get(name string) (object interface{}, err error) {
-	gGuard.Read(func() {
-		object, err = getInstance().Get(name)
-	})
+// TrySet sets an object with a given unique id to registry. It returns an
+// error if name or object does not pass registry constraints.
+func TrySet(name string, object interface{}) error {
+	return getDefault().TrySet(name, object)
+}
 
-	return object, err
+// TrySets sets objects with given unique ids to registry. It returns an error
+// without setting anything if any name or object does not pass registry constraints.
+func TrySets(objects Objects) error {
+	return getDefault().TrySets(objects)
 }
 
-// Gets returns registered objects by given names.
-func Gets(names []string) (objects Objects, err error) {
-	gGuard.Read(func() {
-		objects, err = getInstance().Gets(names)
-	})
+// Use appends given interceptors to registry lookup interceptor chain.
+func Use(interceptors ...Interceptor) {
+	getDefault().Use(interceptors...)
+}
 
-	return objects, err
+// Get returns registered object by given name.
+func Get(name string) (interface{}, error) {
+	return getDefault().Get(name)
+}
+
+// Gets returns registered objects by given names.
+func Gets(names []string) (Objects, error) {
+	return getDefault().Gets(names)
 }
 
 // GetAll returns all registered objects.
-func GetAll() (objects Objects) {
-	gGuard.Read(func() {
-		objects = getInstance().GetAll()
-	})
+func GetAll() Objects {
+	return getDefault().GetAll()
+}
+
+// Entries returns all registered objects with their registration details sorted by name.
+func Entries() []Entry {
+	return getDefault().Entries()
+}
+
+// Encode writes all registered objects encoded with a given codec.
+func Encode(writer io.Writer, codec Codec) error {
+	return getDefault().Encode(writer, codec)
+}
 
-	return objects
+// Decode reads objects encoded with a given codec and sets them to registry.
+func Decode(reader io.Reader, codec Codec) error {
+	return getDefault().Decode(reader, codec)
+}
+
+// ReadFile reads objects from a file encoded with a given codec and sets them to registry.
+func ReadFile(path string, codec Codec) error {
+	return getDefault().ReadFile(path, codec)
+}
+
+// WriteFile writes all registered objects encoded with a given codec to a file.
+func WriteFile(path string, codec Codec) error {
+	return getDefault().WriteFile(path, codec)
 }
 
 // Remove removes registered object.
 func Remove(name string) {
-	gGuard.Write(func() {
-		getInstance().Remove(name)
-	})
+	getDefault().Remove(name)
 }
 
 // Removes removes registered object.
 func Removes(names []string) {
-	gGuard.Write(func() {
-		getInstance().Removes(names)
-	})
+	getDefault().Removes(names)
 }
 
 // RemoveAll removes all registered object.
 func RemoveAll() {
-	gGuard.Write(func() {
-		getInstance().RemoveAll()
-	})
+	getDefault().RemoveAll()
 }
 
 // IsExist returns true if object with given name was registered, otherwise it returns false.
-func IsExist(name string) (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsExist(name)
-	})
-
-	return value
+func IsExist(name string) bool {
+	return getDefault().IsExist(name)
 }
 
 // IsExists returns true if all objects with given names were registered, otherwise it returns false.
-func IsExists(names []string) (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsExists(names)
-	})
-
-	return value
+func IsExists(names []string) bool {
+	return getDefault().IsExists(names)
 }
 
 // IsEmpty returns true if there are no registered objects, otherwise it returns false.
-func IsEmpty() (value bool) {
-	gGuard.Read(func() {
-		value = getInstance().IsEmpty()
-	})
-
-	return value
+func IsEmpty() bool {
+	return getDefault().IsEmpty()
 }
 
 // Size returns number of registered objects.
-func Size() (value int) {
-	gGuard.Read(func() {
-		value = getInstance().Size()
-	})
+func Size() int {
+	return getDefault().Size()
+}
 
-	return value
+// Replace replaces global registry instance with a given one and returns
+// the previous instance. A nil instance is replaced with a new empty registry.
+func Replace(instance *Registry) *Registry {
+	return getDefault().Replace(instance)
 }
 
-// getInstance returns global registry instance.
-func getInstance() *Registry {
+// getDefault returns default global registry.
+func getDefault() *Global {
 	gOnce.Do(func() {
-		gInstance = New()
+		gDefault = Named(DefaultGlobal)
 	})
 
-	return gInstance
+	return gDefault
+}
+
+// getInstance returns default global registry instance. Default global
+// registry must be locked.
+func getInstance() *Registry {
+	return getDefault().instance
 }
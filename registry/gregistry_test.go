@@ -15,9 +15,12 @@
 package registry_test
 
 import (
+	"bytes"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/metrics"
 	"gitlab.com/tymonx/go-patterns/registry"
 )
 
@@ -77,6 +80,20 @@ func TestGlobalRegistrySets(test *testing.T) {
 	assert.Len(test, registry.GetAll(), 2)
 }
 
+func TestGlobalRegistryTrySet(test *testing.T) {
+	defer registry.RemoveAll()
+
+	var object struct{}
+
+	assert.NoError(test, registry.TrySet("object", object))
+	assert.NoError(test, registry.TrySets(registry.Objects{
+		"objectA": object,
+		"objectB": object,
+	}))
+
+	assert.Len(test, registry.GetAll(), 3)
+}
+
 func TestGlobalRegistryIsExist(test *testing.T) {
 	defer registry.RemoveAll()
 
@@ -278,3 +295,106 @@ func TestGlobalRegistryRemoveAll(test *testing.T) {
 
 	assert.Empty(test, objects)
 }
+
+func TestGlobalRegistryUse(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Use(func(name string, next registry.Handler) (interface{}, error) {
+		if name == "intercepted" {
+			return "intercepted", nil
+		}
+
+		return next(name)
+	})
+
+	object, err := registry.Get("intercepted")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "intercepted", object)
+}
+
+func TestGlobalRegistryConfigure(test *testing.T) {
+	defer registry.RemoveAll()
+	defer registry.Configure(registry.WithRecorder(nil))
+
+	recorder := metrics.NewLocalExpvar()
+
+	registry.Configure(registry.WithRecorder(recorder))
+
+	assert.NoError(test, registry.Add("object", "value"))
+
+	_, err := registry.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, int64(1), recorder.Adds())
+	assert.Equal(test, int64(1), recorder.Hits())
+}
+
+func TestGlobalRegistryEntries(test *testing.T) {
+	defer registry.RemoveAll()
+
+	assert.NoError(test, registry.Add("object", "value"))
+
+	entries := registry.Entries()
+
+	assert.Len(test, entries, 1)
+	assert.Equal(test, "object", entries[0].Name)
+	assert.Contains(test, entries[0].Source, "gregistry_test.go:")
+}
+
+func TestGlobalRegistryEncodeDecode(test *testing.T) {
+	defer registry.RemoveAll()
+
+	var buffer bytes.Buffer
+
+	registry.Set("object", "value")
+
+	assert.NoError(test, registry.Encode(&buffer, registry.JSON{}))
+
+	registry.RemoveAll()
+
+	assert.NoError(test, registry.Decode(&buffer, registry.JSON{}))
+	assert.True(test, registry.IsExist("object"))
+}
+
+func TestGlobalRegistryFile(test *testing.T) {
+	defer registry.RemoveAll()
+
+	path := filepath.Join(TempDir(test), "registry.gob")
+
+	registry.Set("object", "value")
+
+	assert.NoError(test, registry.WriteFile(path, registry.Gob{}))
+
+	registry.RemoveAll()
+
+	assert.NoError(test, registry.ReadFile(path, registry.Gob{}))
+	assert.True(test, registry.IsExist("object"))
+}
+
+func TestGlobalRegistryReplace(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Set("object", "global")
+
+	instance := registry.New()
+	instance.Set("object", "replaced")
+
+	previous := registry.Replace(instance)
+
+	object, err := registry.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "replaced", object)
+
+	assert.Same(test, instance, registry.Replace(previous))
+
+	object, err = registry.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+
+	previous = registry.Replace(nil)
+	assert.True(test, registry.IsEmpty())
+	registry.Replace(previous)
+}
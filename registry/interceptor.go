@@ -0,0 +1,38 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+// Handler defines a registry lookup function that returns registered object
+// by given name.
+type Handler func(name string) (object interface{}, err error)
+
+// Interceptor defines a registry lookup middleware. It can rewrite a given
+// name before passing it to the next handler, return its own object without
+// calling the next handler or post-process object and error returned by the
+// next handler. Interceptors are called in order they were added, the first
+// one is the outermost.
+type Interceptor func(name string, next Handler) (object interface{}, err error)
+
+// intercept calls interceptor with a given index or registry lookup if there
+// are no more interceptors left in chain.
+func (r *Registry) intercept(index int, name string) (object interface{}, err error) {
+	if index >= len(r.interceptors) {
+		return r.get(name)
+	}
+
+	return r.interceptors[index](name, func(name string) (interface{}, error) {
+		return r.intercept(index+1, name)
+	})
+}
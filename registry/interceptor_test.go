@@ -0,0 +1,110 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestInterceptorOrder(test *testing.T) {
+	var calls []string
+
+	r := registry.New(registry.WithInterceptors(
+		func(name string, next registry.Handler) (interface{}, error) {
+			calls = append(calls, "first")
+			return next(name)
+		},
+		func(name string, next registry.Handler) (interface{}, error) {
+			calls = append(calls, "second")
+			return next(name)
+		},
+	))
+
+	r.Set("object", "value")
+
+	object, err := r.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", object)
+	assert.Equal(test, []string{"first", "second"}, calls)
+}
+
+func TestInterceptorRewrite(test *testing.T) {
+	r := registry.New().Use(func(name string, next registry.Handler) (interface{}, error) {
+		if name == "legacy" {
+			name = "current"
+		}
+
+		return next(name)
+	})
+
+	r.Set("current", "value")
+
+	object, err := r.Get("legacy")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", object)
+}
+
+func TestInterceptorShortCircuit(test *testing.T) {
+	r := registry.New().Use(func(name string, next registry.Handler) (interface{}, error) {
+		return "stub", nil
+	})
+
+	r.Set("object", "value")
+
+	object, err := r.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "stub", object)
+}
+
+func TestInterceptorFallback(test *testing.T) {
+	r := registry.New().Use(func(name string, next registry.Handler) (interface{}, error) {
+		if object, err := next(name); err == nil {
+			return object, nil
+		}
+
+		return next("default")
+	})
+
+	r.Sets(registry.Objects{
+		"default": "fallback",
+		"object":  "value",
+	})
+
+	objects, err := r.Gets([]string{"object", "missing"})
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", objects["object"])
+	assert.Equal(test, "fallback", objects["missing"])
+}
+
+func TestInterceptorError(test *testing.T) {
+	r := registry.New().Use(func(name string, next registry.Handler) (interface{}, error) {
+		return nil, rterror.New("denied", name)
+	})
+
+	r.Set("object", "value")
+
+	object, err := r.Get("object")
+
+	assert.Error(test, err)
+	assert.Nil(test, object)
+}
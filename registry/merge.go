@@ -0,0 +1,128 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"reflect"
+	"sort"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Strategy defines a merge strategy used to resolve conflicts when merged
+// object was already registered in destination registry.
+type Strategy int
+
+// Merge strategies.
+const (
+	// KeepExisting keeps already registered objects, like Adds without errors.
+	KeepExisting Strategy = iota
+
+	// Overwrite overwrites already registered objects, like Sets.
+	Overwrite
+
+	// ErrorOnConflict returns an error without merging anything if any object
+	// was already registered, like Adds.
+	ErrorOnConflict
+)
+
+// EqualFunc defines a function that returns true if given objects are equal.
+type EqualFunc func(a, b interface{}) bool
+
+// Difference defines names of objects that differ between two registries.
+type Difference struct {
+	Added   Names
+	Removed Names
+	Changed Names
+}
+
+// IsEmpty returns true if there are no differences, otherwise it returns false.
+func (d Difference) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff returns names of objects added, removed and changed in registry b
+// compared to registry a. Objects are compared with a given equal function or
+// with reflect.DeepEqual if it is nil. Names are sorted.
+func Diff(a, b *Registry, equal EqualFunc) Difference {
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+
+	difference := Difference{
+		Added:   Names{},
+		Removed: Names{},
+		Changed: Names{},
+	}
+
+	for name, object := range b.objects {
+		previous, ok := a.objects[name]
+
+		switch {
+		case !ok:
+			difference.Added = append(difference.Added, name)
+		case !equal(previous, object):
+			difference.Changed = append(difference.Changed, name)
+		}
+	}
+
+	for name := range a.objects {
+		if _, ok := b.objects[name]; !ok {
+			difference.Removed = append(difference.Removed, name)
+		}
+	}
+
+	sort.Strings(difference.Added)
+	sort.Strings(difference.Removed)
+	sort.Strings(difference.Changed)
+
+	return difference
+}
+
+// Merge merges objects from source registry to destination registry using
+// a given merge strategy. Objects not passing destination registry
+// constraints are reported as errors.
+func Merge(dst, src *Registry, strategy Strategy) error {
+	switch strategy {
+	case KeepExisting:
+		objects := Objects{}
+
+		for name, object := range src.objects {
+			if !dst.IsExist(name) {
+				objects[name] = object
+			}
+		}
+
+		return dst.Adds(objects)
+	case Overwrite:
+		return dst.setObjects(src.objects)
+	case ErrorOnConflict:
+		errs := make([]interface{}, 0, len(src.objects))
+
+		for name := range src.objects {
+			if dst.IsExist(name) {
+				errs = append(errs, rterror.New("object was already registered", name))
+			}
+		}
+
+		if len(errs) != 0 {
+			return rterror.New("cannot merge objects", errs...)
+		}
+
+		return dst.Adds(src.objects)
+	default:
+		return rterror.New("unknown merge strategy", int(strategy))
+	}
+}
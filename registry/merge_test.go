@@ -0,0 +1,100 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestDiff(test *testing.T) {
+	a := registry.New().Sets(registry.Objects{
+		"objectA": "valueA",
+		"objectB": "valueB",
+		"objectC": []string{"c"},
+	})
+
+	b := registry.New().Sets(registry.Objects{
+		"objectB": "changed",
+		"objectC": []string{"c"},
+		"objectE": "valueE",
+		"objectD": "valueD",
+	})
+
+	difference := registry.Diff(a, b, nil)
+
+	assert.False(test, difference.IsEmpty())
+	assert.Equal(test, registry.Names{"objectD", "objectE"}, difference.Added)
+	assert.Equal(test, registry.Names{"objectA"}, difference.Removed)
+	assert.Equal(test, registry.Names{"objectB"}, difference.Changed)
+}
+
+func TestDiffEqualFunc(test *testing.T) {
+	a := registry.New().Set("object", "VALUE")
+	b := registry.New().Set("object", "value")
+
+	assert.Equal(test, registry.Names{"object"}, registry.Diff(a, b, nil).Changed)
+
+	difference := registry.Diff(a, b, func(x, y interface{}) bool {
+		return strings.EqualFold(x.(string), y.(string))
+	})
+
+	assert.True(test, difference.IsEmpty())
+}
+
+func TestMergeKeepExisting(test *testing.T) {
+	dst := registry.New().Set("objectA", "valueA")
+	src := registry.New().Sets(registry.Objects{"objectA": "changed", "objectB": "valueB"})
+
+	assert.NoError(test, registry.Merge(dst, src, registry.KeepExisting))
+	assert.Equal(test, registry.Objects{"objectA": "valueA", "objectB": "valueB"}, dst.GetAll())
+}
+
+func TestMergeOverwrite(test *testing.T) {
+	dst := registry.New().Set("objectA", "valueA")
+	src := registry.New().Sets(registry.Objects{"objectA": "changed", "objectB": "valueB"})
+
+	assert.NoError(test, registry.Merge(dst, src, registry.Overwrite))
+	assert.Equal(test, registry.Objects{"objectA": "changed", "objectB": "valueB"}, dst.GetAll())
+}
+
+func TestMergeErrorOnConflict(test *testing.T) {
+	dst := registry.New().Set("objectA", "valueA")
+	src := registry.New().Sets(registry.Objects{"objectA": "changed", "objectB": "valueB"})
+
+	assert.Error(test, registry.Merge(dst, src, registry.ErrorOnConflict))
+	assert.Equal(test, registry.Objects{"objectA": "valueA"}, dst.GetAll())
+
+	src.Remove("objectA")
+
+	assert.NoError(test, registry.Merge(dst, src, registry.ErrorOnConflict))
+	assert.Equal(test, registry.Objects{"objectA": "valueA", "objectB": "valueB"}, dst.GetAll())
+}
+
+func TestMergeConstraints(test *testing.T) {
+	dst := registry.NewTyped(ReaderType)
+	src := registry.New().Set("object", "value")
+
+	assert.Error(test, registry.Merge(dst, src, registry.Overwrite))
+	assert.Error(test, registry.Merge(dst, src, registry.KeepExisting))
+	assert.True(test, dst.IsEmpty())
+}
+
+func TestMergeUnknownStrategy(test *testing.T) {
+	assert.Error(test, registry.Merge(registry.New(), registry.New(), registry.Strategy(-1)))
+}
@@ -0,0 +1,200 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"sort"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Handle defines an unique identifier of a single object registered in
+// a multi registry.
+type Handle uint64
+
+// Multi defines a multi registry that can register many objects under the
+// same name, for example contributions to an extension point.
+type Multi struct {
+	registry      *Registry
+	contributions map[string][]contribution
+	handles       map[Handle]string
+	next          Handle
+}
+
+// contribution defines a single object registered in a multi registry.
+type contribution struct {
+	handle   Handle
+	priority int
+	object   interface{}
+}
+
+// NewMulti creates a new multi registry object. Given registry options are
+// applied to names of registered objects.
+func NewMulti(options ...Option) *Multi {
+	return &Multi{
+		registry:      New(options...),
+		contributions: map[string][]contribution{},
+		handles:       map[Handle]string{},
+	}
+}
+
+// Add adds an object under a given name with the default zero priority.
+// It returns handle that can be used to remove that object.
+func (m *Multi) Add(name string, object interface{}) (Handle, error) {
+	return m.AddWithPriority(name, object, 0)
+}
+
+// AddWithPriority adds an object under a given name with a given priority.
+// Objects with higher priority are returned first, objects with the same
+// priority are returned in order they were added. It returns handle that can
+// be used to remove that object.
+func (m *Multi) AddWithPriority(name string, object interface{}, priority int) (Handle, error) {
+	key, err := m.registry.Key(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err = m.registry.Validate(key, object); err != nil {
+		return 0, err
+	}
+
+	m.next++
+
+	contributions := append(m.contributions[key], contribution{
+		handle:   m.next,
+		priority: priority,
+		object:   object,
+	})
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].priority > contributions[j].priority
+	})
+
+	m.contributions[key] = contributions
+	m.handles[m.next] = key
+
+	return m.next, nil
+}
+
+// Get returns object with the highest priority registered under a given name.
+func (m *Multi) Get(name string) (interface{}, error) {
+	key, err := m.registry.Key(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, ok := m.contributions[key]
+
+	if !ok {
+		return nil, rterror.New("object was not registered", name)
+	}
+
+	return contributions[0].object, nil
+}
+
+// GetAll returns all objects registered under a given name in priority order.
+func (m *Multi) GetAll(name string) []interface{} {
+	key, err := m.registry.Key(name)
+
+	if err != nil {
+		return []interface{}{}
+	}
+
+	objects := make([]interface{}, 0, len(m.contributions[key]))
+
+	for _, c := range m.contributions[key] {
+		objects = append(objects, c.object)
+	}
+
+	return objects
+}
+
+// Names returns sorted names with registered objects.
+func (m *Multi) Names() Names {
+	names := make(Names, 0, len(m.contributions))
+
+	for name := range m.contributions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Remove removes object registered with a given handle.
+func (m *Multi) Remove(handle Handle) *Multi {
+	key, ok := m.handles[handle]
+
+	if !ok {
+		return m
+	}
+
+	delete(m.handles, handle)
+
+	contributions := m.contributions[key]
+
+	for i, c := range contributions {
+		if c.handle == handle {
+			contributions = append(contributions[:i:i], contributions[i+1:]...)
+			break
+		}
+	}
+
+	if len(contributions) == 0 {
+		delete(m.contributions, key)
+	} else {
+		m.contributions[key] = contributions
+	}
+
+	return m
+}
+
+// RemoveAll removes all objects registered under a given name.
+func (m *Multi) RemoveAll(name string) *Multi {
+	key, err := m.registry.Key(name)
+
+	if err != nil {
+		return m
+	}
+
+	for _, c := range m.contributions[key] {
+		delete(m.handles, c.handle)
+	}
+
+	delete(m.contributions, key)
+
+	return m
+}
+
+// IsExist returns true if any object was registered under a given name, otherwise it returns false.
+func (m *Multi) IsExist(name string) bool {
+	key, err := m.registry.Key(name)
+
+	if err != nil {
+		return false
+	}
+
+	_, ok := m.contributions[key]
+
+	return ok
+}
+
+// Size returns number of all registered objects.
+func (m *Multi) Size() int {
+	return len(m.handles)
+}
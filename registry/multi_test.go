@@ -0,0 +1,110 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestMultiAdd(test *testing.T) {
+	m := registry.NewMulti()
+
+	handleA, err := m.Add("shutdown.hook", "hookA")
+
+	assert.NoError(test, err)
+
+	handleB, err := m.Add("shutdown.hook", "hookB")
+
+	assert.NoError(test, err)
+	assert.NotEqual(test, handleA, handleB)
+	assert.Equal(test, []interface{}{"hookA", "hookB"}, m.GetAll("shutdown.hook"))
+	assert.Equal(test, 2, m.Size())
+	assert.True(test, m.IsExist("shutdown.hook"))
+	assert.False(test, m.IsExist("http.middleware"))
+	assert.Empty(test, m.GetAll("http.middleware"))
+}
+
+func TestMultiPriority(test *testing.T) {
+	m := registry.NewMulti()
+
+	for _, c := range []struct {
+		object   string
+		priority int
+	}{
+		{"low", -1},
+		{"defaultA", 0},
+		{"high", 10},
+		{"defaultB", 0},
+	} {
+		_, err := m.AddWithPriority("http.middleware", c.object, c.priority)
+		assert.NoError(test, err)
+	}
+
+	assert.Equal(test, []interface{}{"high", "defaultA", "defaultB", "low"}, m.GetAll("http.middleware"))
+
+	object, err := m.Get("http.middleware")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "high", object)
+}
+
+func TestMultiRemove(test *testing.T) {
+	m := registry.NewMulti()
+
+	handleA, _ := m.Add("shutdown.hook", "hookA")
+	handleB, _ := m.Add("shutdown.hook", "hookB")
+	_, _ = m.Add("http.middleware", "middleware")
+
+	assert.Same(test, m, m.Remove(handleA))
+	assert.Equal(test, []interface{}{"hookB"}, m.GetAll("shutdown.hook"))
+
+	m.Remove(handleA).Remove(handleB)
+
+	assert.False(test, m.IsExist("shutdown.hook"))
+	assert.Equal(test, registry.Names{"http.middleware"}, m.Names())
+
+	m.RemoveAll("http.middleware")
+
+	assert.Zero(test, m.Size())
+	assert.Empty(test, m.Names())
+
+	_, err := m.Get("http.middleware")
+	assert.Error(test, err)
+}
+
+func TestMultiOptions(test *testing.T) {
+	m := registry.NewMulti(
+		registry.WithNormalizer(registry.FoldCase),
+		registry.WithNameValidator(registry.Reserved("all")),
+	)
+
+	_, err := m.Add("Shutdown.Hook", "hook")
+
+	assert.NoError(test, err)
+	assert.Equal(test, []interface{}{"hook"}, m.GetAll("SHUTDOWN.HOOK"))
+
+	_, err = m.Add("all", "hook")
+
+	assert.Error(test, err)
+	assert.False(test, m.IsExist("all"))
+	assert.Empty(test, m.GetAll("all"))
+	assert.Same(test, m, m.RemoveAll("all"))
+
+	_, err = m.Get("all")
+	assert.Error(test, err)
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"strings"
+)
+
+// Normalizer defines a name normalizer function. Any function with the same
+// signature can be used, for example the norm.NFC.String function from the
+// golang.org/x/text/unicode/norm package for Unicode normalization.
+type Normalizer func(name string) string
+
+// Normalizers returns a name normalizer that applies given normalizers in order.
+func Normalizers(normalizers ...Normalizer) Normalizer {
+	return func(name string) string {
+		for _, normalizer := range normalizers {
+			name = normalizer(name)
+		}
+
+		return name
+	}
+}
+
+// FoldCase returns a name with all Unicode letters mapped to their lower case.
+func FoldCase(name string) string {
+	return strings.ToLower(name)
+}
+
+// TrimSpace returns a name with all leading and trailing white spaces removed.
+func TrimSpace(name string) string {
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestNormalizerFoldCase(test *testing.T) {
+	assert.Equal(test, "json", registry.FoldCase("JSON"))
+	assert.Equal(test, "źdźbło", registry.FoldCase("ŹDŹBŁO"))
+}
+
+func TestNormalizerTrimSpace(test *testing.T) {
+	assert.Equal(test, "json", registry.TrimSpace(" \tjson\n"))
+}
+
+func TestNormalizers(test *testing.T) {
+	normalizer := registry.Normalizers(registry.TrimSpace, registry.FoldCase)
+
+	assert.Equal(test, "json", normalizer(" JSON "))
+	assert.Equal(test, "json", registry.Normalizers()("json"))
+}
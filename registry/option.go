@@ -0,0 +1,69 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"gitlab.com/tymonx/go-patterns/metrics"
+)
+
+// Option defines a registry option used to configure a registry object.
+type Option func(r *Registry)
+
+// WithNormalizer sets a name normalizer applied to every name passed to a
+// registry before it is used as a registry key.
+func WithNormalizer(normalizer Normalizer) Option {
+	return func(r *Registry) {
+		r.normalizer = normalizer
+	}
+}
+
+// WithNameValidator sets a name validator applied to every normalized name
+// passed to a registry.
+func WithNameValidator(validator NameValidator) Option {
+	return func(r *Registry) {
+		r.nameValidator = validator
+	}
+}
+
+// WithInterceptors appends given interceptors to registry lookup interceptor chain.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(r *Registry) {
+		r.Use(interceptors...)
+	}
+}
+
+// WithRecorder sets a metrics recorder used to record registry lookups and
+// mutations. By default registry uses the no-op metrics recorder.
+func WithRecorder(recorder metrics.Recorder) Option {
+	return func(r *Registry) {
+		if recorder == nil {
+			recorder = metrics.Nop{}
+		}
+
+		r.recorder = recorder
+	}
+}
+
+// WithSuggestionDistance sets the maximum edit distance between a name that was
+// not registered and registered names suggested in the returned error. Zero
+// disables suggestions and it is the default. With suggestions enabled every
+// failed lookup compares a requested name with all registered names of
+// a similar length while the registry is locked, which adds cost to code
+// where failed lookups are routine.
+func WithSuggestionDistance(distance int) Option {
+	return func(r *Registry) {
+		r.suggestionDistance = distance
+	}
+}
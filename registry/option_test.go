@@ -0,0 +1,131 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/metrics"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestOptionWithNormalizer(test *testing.T) {
+	var object struct{}
+
+	r := registry.New(registry.WithNormalizer(registry.Normalizers(registry.TrimSpace, registry.FoldCase)))
+
+	assert.NoError(test, r.Add("JSON", object))
+	assert.Error(test, r.Add(" json ", object))
+	assert.True(test, r.IsExist("json"))
+	assert.True(test, r.IsExist(" Json"))
+	assert.Contains(test, r.GetAll(), "json")
+
+	ret, err := r.Get("jSoN ")
+
+	assert.NoError(test, err)
+	assert.Equal(test, object, ret)
+
+	r.Set(" JSON", object)
+	assert.Equal(test, 1, r.Size())
+
+	r.Remove("Json")
+	assert.True(test, r.IsEmpty())
+}
+
+func TestOptionWithNameValidator(test *testing.T) {
+	var object struct{}
+
+	r := registry.New(registry.WithNameValidator(registry.MatchName(regexp.MustCompile(`^[a-z]+$`))))
+
+	assert.NoError(test, r.Add("json", object))
+	assert.Error(test, r.Add("JSON", object))
+	assert.Panics(test, func() { r.Set("JSON", object) })
+	assert.False(test, r.IsExist("JSON"))
+
+	ret, err := r.Get("JSON")
+
+	assert.Error(test, err)
+	assert.Nil(test, ret)
+
+	r.Remove("JSON")
+	assert.Equal(test, 1, r.Size())
+}
+
+func TestOptionWithNormalizerCollision(test *testing.T) {
+	r := registry.New(registry.WithNormalizer(registry.FoldCase))
+
+	err := r.TrySets(registry.Objects{
+		"JSON": "a",
+		"json": "b",
+	})
+
+	assert.Error(test, err)
+	assert.Contains(test, err.Error(), "JSON json")
+	assert.True(test, r.IsEmpty())
+
+	assert.NoError(test, r.TrySets(registry.Objects{
+		"JSON": "a",
+		"yaml": "b",
+	}))
+
+	assert.Equal(test, 2, r.Size())
+}
+
+func TestOptionWithNormalizerAndNameValidator(test *testing.T) {
+	var object struct{}
+
+	r := registry.New(
+		registry.WithNormalizer(registry.FoldCase),
+		registry.WithNameValidator(registry.Reserved("default")),
+	)
+
+	assert.Error(test, r.Add("Default", object))
+	assert.NoError(test, r.Add("JSON", object))
+	assert.True(test, r.IsExist("json"))
+}
+
+func TestOptionWithRecorder(test *testing.T) {
+	recorder := metrics.NewLocalExpvar()
+
+	r := registry.New(registry.WithRecorder(recorder))
+
+	assert.Same(test, recorder, r.Recorder())
+	assert.NoError(test, r.Add("objectA", "value"))
+	assert.Error(test, r.Add("objectA", "value"))
+	r.Set("objectB", "value")
+
+	_, err := r.Get("objectA")
+	assert.NoError(test, err)
+
+	_, err = r.Get("objectC")
+	assert.Error(test, err)
+
+	r.Remove("objectA").Remove("objectC").RemoveAll()
+
+	assert.Equal(test, int64(1), recorder.Adds())
+	assert.Equal(test, int64(1), recorder.Sets())
+	assert.Equal(test, int64(2), recorder.Gets())
+	assert.Equal(test, int64(1), recorder.Hits())
+	assert.Equal(test, int64(1), recorder.Misses())
+	assert.Equal(test, int64(2), recorder.Removes())
+}
+
+func TestOptionWithRecorderNil(test *testing.T) {
+	r := registry.New(registry.WithRecorder(nil))
+
+	assert.Equal(test, metrics.Nop{}, r.Recorder())
+}
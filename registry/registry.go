@@ -15,7 +15,10 @@
 package registry
 
 import (
+	"reflect"
+
 	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/metrics"
 )
 
 // Names defines a list of names.
@@ -26,23 +29,103 @@ type Objects map[string]interface{}
 
 // Registry defines a registry object that can register objects.
 type Registry struct {
-	objects Objects
+	objects            Objects
+	sources            map[string]string
+	validator          Validator
+	normalizer         Normalizer
+	nameValidator      NameValidator
+	interceptors       []Interceptor
+	recorder           metrics.Recorder
+	suggestionDistance int
 }
 
 // New creates a new registry object.
-func New() *Registry {
-	return &Registry{
-		objects: Objects{},
+func New(options ...Option) *Registry {
+	r := &Registry{
+		objects:  Objects{},
+		sources:  map[string]string{},
+		recorder: metrics.Nop{},
+	}
+
+	return r.Configure(options...)
+}
+
+// NewConstrained creates a new registry object that accepts only objects
+// passing a given validator.
+func NewConstrained(validator Validator, options ...Option) *Registry {
+	r := New(options...)
+	r.validator = validator
+
+	return r
+}
+
+// NewTyped creates a new registry object that accepts only objects
+// assignable to a given type. It panics if a given type is nil.
+func NewTyped(kind reflect.Type, options ...Option) *Registry {
+	return NewConstrained(AssignableTo(kind), options...)
+}
+
+// Configure applies given options to registry. Options changing name
+// normalization or validation should be applied before any object is registered.
+func (r *Registry) Configure(options ...Option) *Registry {
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Recorder returns metrics recorder used by registry.
+func (r *Registry) Recorder() metrics.Recorder {
+	return r.recorder
+}
+
+// Key returns normalized name used as a registry key. It returns an error if
+// name does not pass registry name validator.
+func (r *Registry) Key(name string) (string, error) {
+	if r.normalizer != nil {
+		name = r.normalizer(name)
+	}
+
+	if r.nameValidator != nil {
+		if err := r.nameValidator(name); err != nil {
+			return name, rterror.New("invalid name", name, err)
+		}
+	}
+
+	return name, nil
+}
+
+// Validate returns an error if object with a given name cannot be registered.
+func (r *Registry) Validate(name string, object interface{}) error {
+	if r.validator == nil {
+		return nil
 	}
+
+	if err := r.validator(name, object); err != nil {
+		return rterror.New("object was rejected", name, err)
+	}
+
+	return nil
 }
 
 // Add adds an object with a given unique id to registry.
-func (r *Registry) Add(name string, object interface{}) error {
-	if r.IsExist(name) {
+func (r *Registry) Add(name string, object interface{}) (err error) {
+	if name, err = r.Key(name); err != nil {
+		return err
+	}
+
+	if _, ok := r.objects[name]; ok {
 		return rterror.New("object was already registered", name)
 	}
 
+	if err = r.Validate(name, object); err != nil {
+		return err
+	}
+
 	r.objects[name] = object
+	r.sources[name] = callSite()
+	r.recorder.Add(name)
 
 	return nil
 }
@@ -65,26 +148,64 @@ func (r *Registry) Adds(objects Objects) error {
 }
 
 // Set sets an object with a given unique id to registry.
+// It panics if name or object does not pass registry constraints. Use TrySet
+// to get an error instead.
 func (r *Registry) Set(name string, object interface{}) *Registry {
-	r.objects[name] = object
-	return r
+	return r.Sets(Objects{name: object})
 }
 
 // Sets sets objects with given unique ids to registry.
+// It panics without setting anything if any name or object does not pass
+// registry constraints. Use TrySets to get an error instead.
 func (r *Registry) Sets(objects Objects) *Registry {
-	for name, object := range objects {
-		r.Set(name, object)
+	if err := r.setObjects(objects); err != nil {
+		panic(err)
 	}
 
 	return r
 }
 
+// TrySet sets an object with a given unique id to registry. It returns an
+// error if name or object does not pass registry constraints.
+func (r *Registry) TrySet(name string, object interface{}) error {
+	return r.setObjects(Objects{name: object})
+}
+
+// TrySets sets objects with given unique ids to registry. It returns an error
+// without setting anything if any name or object does not pass registry constraints.
+func (r *Registry) TrySets(objects Objects) error {
+	return r.setObjects(objects)
+}
+
+// Use appends given interceptors to registry lookup interceptor chain.
+func (r *Registry) Use(interceptors ...Interceptor) *Registry {
+	r.interceptors = append(r.interceptors, interceptors...)
+	return r
+}
+
 // Get returns registered object by given name.
 func (r *Registry) Get(name string) (object interface{}, err error) {
+	object, err = r.intercept(0, name)
+	r.recorder.Get(name, err == nil)
+
+	return object, err
+}
+
+// get returns registered object by given name without calling interceptors.
+func (r *Registry) get(name string) (object interface{}, err error) {
 	var ok bool
 
+	if name, err = r.Key(name); err != nil {
+		return nil, err
+	}
+
 	if object, ok = r.objects[name]; !ok {
-		return nil, rterror.New("object was not registered", name)
+		notRegistered := &NotRegisteredError{
+			Name:        name,
+			Suggestions: r.suggest(name),
+		}
+
+		return nil, rterror.New(notRegistered.Error(), notRegistered)
 	}
 
 	return object, nil
@@ -128,11 +249,19 @@ func (r *Registry) GetAll() Objects {
 
 // Remove removes registered object.
 func (r *Registry) Remove(name string) *Registry {
+	name, err := r.Key(name)
+
+	if err != nil {
+		return r
+	}
+
 	if _, ok := r.objects[name]; !ok {
 		return r
 	}
 
 	delete(r.objects, name)
+	delete(r.sources, name)
+	r.recorder.Remove(name)
 
 	return r
 }
@@ -148,13 +277,26 @@ func (r *Registry) Removes(names []string) *Registry {
 
 // RemoveAll removes all registered objects.
 func (r *Registry) RemoveAll() *Registry {
+	for name := range r.objects {
+		r.recorder.Remove(name)
+	}
+
 	r.objects = Objects{}
+	r.sources = map[string]string{}
+
 	return r
 }
 
 // IsExist returns true if object with given name was registered, otherwise it returns false.
 func (r *Registry) IsExist(name string) bool {
+	name, err := r.Key(name)
+
+	if err != nil {
+		return false
+	}
+
 	_, ok := r.objects[name]
+
 	return ok
 }
 
@@ -178,3 +320,65 @@ func (r *Registry) IsEmpty() bool {
 func (r *Registry) Size() int {
 	return len(r.objects)
 }
+
+// ValidateObjects returns an error if any name or object does not pass
+// registry constraints or if different names are normalized to the same key.
+// Objects passing it can be set to registry without an error.
+func (r *Registry) ValidateObjects(objects Objects) error {
+	_, err := r.keys(objects)
+	return err
+}
+
+// keys returns registry keys of given object names. It returns an error if
+// any name or object does not pass registry constraints or if different names
+// are normalized to the same key.
+func (r *Registry) keys(objects Objects) (map[string]string, error) {
+	keys := make(map[string]string, len(objects))
+	names := make(map[string]string, len(objects))
+
+	for name, object := range objects {
+		key, err := r.Key(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if other, ok := names[key]; ok {
+			if other > name {
+				other, name = name, other
+			}
+
+			return nil, rterror.New("names are normalized to the same key", other, name, key)
+		}
+
+		if err = r.Validate(key, object); err != nil {
+			return nil, err
+		}
+
+		keys[name] = key
+		names[key] = name
+	}
+
+	return keys, nil
+}
+
+// setObjects sets objects with given unique ids to registry. It returns an
+// error without setting anything if any name or object does not pass registry
+// constraints or if different names are normalized to the same key.
+func (r *Registry) setObjects(objects Objects) error {
+	keys, err := r.keys(objects)
+
+	if err != nil {
+		return err
+	}
+
+	source := callSite()
+
+	for name, object := range objects {
+		r.objects[keys[name]] = object
+		r.sources[keys[name]] = source
+		r.recorder.Set(keys[name])
+	}
+
+	return nil
+}
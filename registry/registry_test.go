@@ -15,9 +15,11 @@
 package registry_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-error/rterror"
 	"gitlab.com/tymonx/go-patterns/registry"
 )
 
@@ -283,3 +285,90 @@ func TestRegistryRemoveAll(test *testing.T) {
 
 	assert.Empty(test, objects)
 }
+
+func TestRegistryNewConstrained(test *testing.T) {
+	r := registry.NewConstrained(func(name string, object interface{}) error {
+		if name == "rejected" {
+			return rterror.New("rejected")
+		}
+
+		return nil
+	})
+
+	assert.NoError(test, r.Add("object", struct{}{}))
+	assert.Error(test, r.Add("rejected", struct{}{}))
+	assert.Len(test, r.GetAll(), 1)
+}
+
+func TestRegistryNewTypedAdd(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.NoError(test, r.Add("reader", strings.NewReader("")))
+	assert.Error(test, r.Add("object", struct{}{}))
+	assert.Len(test, r.GetAll(), 1)
+}
+
+func TestRegistryNewTypedAdds(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.Error(test, r.Adds(registry.Objects{
+		"reader": strings.NewReader(""),
+		"object": struct{}{},
+	}))
+
+	assert.True(test, r.IsExist("reader"))
+	assert.False(test, r.IsExist("object"))
+}
+
+func TestRegistryNewTypedSet(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.NotPanics(test, func() { r.Set("reader", strings.NewReader("")) })
+	assert.Panics(test, func() { r.Set("object", struct{}{}) })
+	assert.Len(test, r.GetAll(), 1)
+}
+
+func TestRegistryNewTypedNil(test *testing.T) {
+	assert.Panics(test, func() { registry.NewTyped(nil) })
+}
+
+func TestRegistryNewTypedTrySet(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.NoError(test, r.TrySet("reader", strings.NewReader("")))
+	assert.Error(test, r.TrySet("object", struct{}{}))
+	assert.Len(test, r.GetAll(), 1)
+}
+
+func TestRegistryNewTypedTrySets(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.Error(test, r.TrySets(registry.Objects{
+		"reader": strings.NewReader(""),
+		"object": struct{}{},
+	}))
+
+	assert.True(test, r.IsEmpty())
+}
+
+func TestRegistryNewTypedSets(test *testing.T) {
+	r := registry.NewTyped(ReaderType)
+
+	assert.Panics(test, func() {
+		r.Sets(registry.Objects{
+			"reader": strings.NewReader(""),
+			"object": struct{}{},
+		})
+	})
+
+	assert.True(test, r.IsEmpty())
+}
+
+func TestRegistryValidateObjects(test *testing.T) {
+	r := registry.New(registry.WithNormalizer(registry.FoldCase), registry.WithNameValidator(registry.Reserved("default")))
+
+	assert.NoError(test, r.ValidateObjects(registry.Objects{"A": 1, "b": 2}))
+	assert.Error(test, r.ValidateObjects(registry.Objects{"A": 1, "a": 2}))
+	assert.Error(test, r.ValidateObjects(registry.Objects{"DEFAULT": 1}))
+	assert.True(test, r.IsEmpty())
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// TempDir creates a temporary directory removed when test completes.
+func TempDir(test *testing.T) string {
+	path, err := ioutil.TempDir("", "registry")
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	return path
+}
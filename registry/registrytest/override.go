@@ -0,0 +1,54 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrytest
+
+import (
+	"testing"
+
+	"gitlab.com/tymonx/go-patterns/internal/testowner"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+var gOwner = testowner.New("registrytest", "global registry") // nolint: gochecknoglobals
+
+// Override replaces the global registry instance with an isolated one holding
+// only given objects for the duration of a test. The previous instance is
+// restored when the test and all its subtests complete.
+//
+// The global registry is shared by the whole process, so tests overriding it
+// must not run in parallel with each other. Override fails the test when the
+// global registry is already overridden by another running test that is not
+// its parent. Nested overrides within the same test or its subtests are allowed.
+func Override(test testing.TB, objects registry.Objects, options ...registry.Option) {
+	test.Helper()
+
+	instance := registry.New(options...)
+
+	if err := instance.Adds(objects); err != nil {
+		test.Fatalf("registrytest: cannot override global registry: %v", err)
+		return
+	}
+
+	if !gOwner.Acquire(test) {
+		return
+	}
+
+	previous := registry.Replace(instance)
+
+	test.Cleanup(func() {
+		registry.Replace(previous)
+		gOwner.Release()
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrytest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+	"gitlab.com/tymonx/go-patterns/registry/registrytest"
+)
+
+type recorder struct {
+	testing.TB
+	failure  string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, arguments ...interface{}) {
+	r.failure = fmt.Sprintf(format, arguments...)
+}
+
+func (r *recorder) Cleanup(cleanup func()) {
+	r.cleanups = append(r.cleanups, cleanup)
+}
+
+func TestOverride(test *testing.T) {
+	defer registry.RemoveAll()
+
+	registry.Set("object", "global")
+
+	test.Run("override", func(test *testing.T) {
+		registrytest.Override(test, registry.Objects{
+			"object": "overridden",
+			"other":  "overridden",
+		})
+
+		object, err := registry.Get("object")
+
+		assert.NoError(test, err)
+		assert.Equal(test, "overridden", object)
+		assert.Equal(test, 2, registry.Size())
+
+		registry.Set("leaked", "value")
+	})
+
+	object, err := registry.Get("object")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "global", object)
+	assert.False(test, registry.IsExist("other"))
+	assert.False(test, registry.IsExist("leaked"))
+}
+
+func TestOverrideWithOptions(test *testing.T) {
+	registrytest.Override(test, registry.Objects{"Object": "value"},
+		registry.WithNormalizer(registry.FoldCase))
+
+	assert.True(test, registry.IsExist("OBJECT"))
+}
+
+func TestOverrideInvalidObjects(test *testing.T) {
+	fake := &recorder{}
+
+	registrytest.Override(fake, registry.Objects{"default": "value"},
+		registry.WithNameValidator(registry.Reserved("default")))
+
+	assert.Contains(test, fake.failure, "cannot override")
+	assert.Empty(test, fake.cleanups)
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultSuggestionDistance defines a recommended maximum edit distance between
+// a name that was not registered and registered names suggested instead. It
+// is meant to be used with WithSuggestionDistance, suggestions are disabled
+// by default.
+const DefaultSuggestionDistance = 2
+
+// maxSuggestions defines the maximum number of suggested names.
+const maxSuggestions = 3
+
+// NotRegisteredError defines an error returned when an object with a given
+// name was not registered. It carries registered names similar to the
+// requested one, the most similar first.
+type NotRegisteredError struct {
+	Name        string
+	Suggestions Names
+}
+
+// Error returns error message with suggested names.
+func (e *NotRegisteredError) Error() string {
+	message := "object was not registered " + e.Name
+
+	if len(e.Suggestions) != 0 {
+		message += ", did you mean " + strings.Join(e.Suggestions, " or ") + "?"
+	}
+
+	return message
+}
+
+// suggest returns registered names within the suggestion distance from
+// a given normalized name, the most similar first.
+func (r *Registry) suggest(name string) Names {
+	if r.suggestionDistance <= 0 {
+		return nil
+	}
+
+	distances := map[string]int{}
+
+	length := utf8.RuneCountInString(name)
+
+	for key := range r.objects {
+		// Names differing in length more than the suggestion distance cannot
+		// be within it, skip them without computing the edit distance
+		if abs(utf8.RuneCountInString(key)-length) > r.suggestionDistance {
+			continue
+		}
+
+		if d := distance(name, key); d <= r.suggestionDistance {
+			distances[key] = d
+		}
+	}
+
+	names := make(Names, 0, len(distances))
+
+	for key := range distances {
+		names = append(names, key)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if distances[names[i]] != distances[names[j]] {
+			return distances[names[i]] < distances[names[j]]
+		}
+
+		return names[i] < names[j]
+	})
+
+	if len(names) > maxSuggestions {
+		names = names[:maxSuggestions]
+	}
+
+	return names
+}
+
+// distance returns the Levenshtein edit distance between given strings.
+func distance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+// abs returns the absolute value of a given value.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
+
+// min returns the smallest of given values.
+func min(values ...int) int {
+	result := values[0]
+
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+func TestSuggestionGet(test *testing.T) {
+	r := registry.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).Sets(registry.Objects{
+		"postgres": 1,
+		"postgis":  2,
+		"mysql":    3,
+	})
+
+	_, err := r.Get("postgress")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, "postgress", notRegistered.Name)
+	assert.Equal(test, registry.Names{"postgres"}, notRegistered.Suggestions)
+	assert.Contains(test, err.Error(), "did you mean postgres?")
+}
+
+func TestSuggestionOrder(test *testing.T) {
+	r := registry.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).Sets(registry.Objects{
+		"objectA": 1,
+		"objectB": 2,
+		"objAB":   3,
+		"other":   4,
+	})
+
+	_, err := r.Get("objectC")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, registry.Names{"objectA", "objectB"}, notRegistered.Suggestions)
+	assert.Contains(test, err.Error(), "did you mean objectA or objectB?")
+}
+
+func TestSuggestionNone(test *testing.T) {
+	r := registry.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).Set("postgres", 1)
+
+	_, err := r.Get("sqlite")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Empty(test, notRegistered.Suggestions)
+	assert.NotContains(test, err.Error(), "did you mean")
+}
+
+func TestSuggestionDistance(test *testing.T) {
+	r := registry.New().Set("postgres", 1)
+
+	_, err := r.Get("postgress")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Empty(test, notRegistered.Suggestions)
+
+	r.Configure(registry.WithSuggestionDistance(4))
+
+	_, err = r.Get("postgr")
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, registry.Names{"postgres"}, notRegistered.Suggestions)
+}
+
+func TestSuggestionNormalized(test *testing.T) {
+	r := registry.New(registry.WithNormalizer(registry.FoldCase),
+		registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).Set("Postgres", 1)
+
+	_, err := r.Get("POSTGRESS")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, "postgress", notRegistered.Name)
+	assert.Equal(test, registry.Names{"postgres"}, notRegistered.Suggestions)
+}
+
+func TestSuggestionUnicode(test *testing.T) {
+	r := registry.New(registry.WithSuggestionDistance(registry.DefaultSuggestionDistance)).Set("zażółć", 1)
+
+	_, err := r.Get("zazółć")
+
+	var notRegistered *registry.NotRegisteredError
+
+	assert.True(test, errors.As(err, &notRegistered))
+	assert.Equal(test, registry.Names{"zażółć"}, notRegistered.Suggestions)
+}
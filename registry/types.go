@@ -0,0 +1,96 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/gob"
+	"fmt"
+	"reflect"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/guard"
+)
+
+var gTypes = map[string]reflect.Type{}     // nolint: gochecknoglobals
+var gTypeNames = map[reflect.Type]string{} // nolint: gochecknoglobals
+var gTypesGuard guard.Guard                // nolint: gochecknoglobals
+
+// RegisterType registers type of a given object under a given unique name,
+// allowing objects of that type stored as interface{} values to be encoded
+// and decoded by codecs. It also registers type with the gob package.
+func RegisterType(name string, object interface{}) (err error) {
+	if object == nil {
+		return rterror.New("object cannot be nil", name)
+	}
+
+	kind := reflect.TypeOf(object)
+
+	gTypesGuard.Write(func() {
+		if registered, ok := gTypes[name]; ok {
+			if registered != kind {
+				err = rterror.New("type name was already registered", name, registered.String())
+			}
+
+			return
+		}
+
+		if registered, ok := gTypeNames[kind]; ok {
+			err = rterror.New("type was already registered", kind.String(), registered)
+			return
+		}
+
+		if err = registerGob(name, object); err != nil {
+			return
+		}
+
+		gTypes[name] = kind
+		gTypeNames[kind] = name
+	})
+
+	return err
+}
+
+// registerGob registers type of a given object under a given name with the
+// gob package. It returns an error instead of panicking if gob already has
+// the type or the name registered differently.
+func registerGob(name string, object interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = rterror.New("cannot register type with gob", name, fmt.Sprint(r))
+		}
+	}()
+
+	gob.RegisterName(name, object)
+
+	return nil
+}
+
+// typeOf returns registered type by given name.
+func typeOf(name string) (kind reflect.Type, ok bool) {
+	gTypesGuard.Read(func() {
+		kind, ok = gTypes[name]
+	})
+
+	return kind, ok
+}
+
+// typeName returns registered name of a given type.
+func typeName(kind reflect.Type) (name string, ok bool) {
+	gTypesGuard.Read(func() {
+		name, ok = gTypeNames[kind]
+	})
+
+	return name, ok
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+type TypesConfig struct {
+	Value int
+}
+
+type TypesOther struct {
+	Value int
+}
+
+type TypesGob struct {
+	Value int
+}
+
+func TestRegisterType(test *testing.T) {
+	assert.NoError(test, registry.RegisterType("types.config", TypesConfig{}))
+	assert.NoError(test, registry.RegisterType("types.config", TypesConfig{}))
+	assert.Error(test, registry.RegisterType("types.config", TypesOther{}))
+	assert.Error(test, registry.RegisterType("types.other", TypesConfig{}))
+	assert.Error(test, registry.RegisterType("types.nil", nil))
+}
+
+func TestRegisterTypeGob(test *testing.T) {
+	gob.Register(TypesGob{})
+
+	err := registry.RegisterType("types.gob", TypesGob{})
+
+	assert.Error(test, err)
+	assert.Contains(test, err.Error(), "cannot register type with gob")
+	assert.Error(test, registry.RegisterType("types.gob", TypesGob{}))
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"gitlab.com/tymonx/go-error/rterror"
+)
+
+// Validator defines an object validator function. It returns an error if
+// object with a given name cannot be registered.
+type Validator func(name string, object interface{}) error
+
+// AssignableTo returns an object validator that accepts only objects
+// assignable to a given type. For interface types it accepts only objects
+// implementing that interface. It panics if a given type is nil.
+func AssignableTo(kind reflect.Type) Validator {
+	if kind == nil {
+		panic(rterror.New("type cannot be nil"))
+	}
+
+	return func(name string, object interface{}) error {
+		if object == nil {
+			return rterror.New("object cannot be nil", name)
+		}
+
+		if !reflect.TypeOf(object).AssignableTo(kind) {
+			return rterror.New("object is not assignable to type", name, reflect.TypeOf(object).String(), kind.String())
+		}
+
+		return nil
+	}
+}
+
+// NameValidator defines a name validator function. It returns an error if
+// a given name cannot be used as a registry key.
+type NameValidator func(name string) error
+
+// NameValidators returns a name validator that applies given validators in order.
+func NameValidators(validators ...NameValidator) NameValidator {
+	return func(name string) error {
+		for _, validator := range validators {
+			if err := validator(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// MatchName returns a name validator that accepts only names matching a given
+// regular expression.
+func MatchName(pattern *regexp.Regexp) NameValidator {
+	return func(name string) error {
+		if !pattern.MatchString(name) {
+			return rterror.New("name does not match pattern", name, pattern.String())
+		}
+
+		return nil
+	}
+}
+
+// MaxLength returns a name validator that accepts only names with a number of
+// characters not greater than a given length.
+func MaxLength(length int) NameValidator {
+	return func(name string) error {
+		if utf8.RuneCountInString(name) > length {
+			return rterror.New("name is too long", name, length)
+		}
+
+		return nil
+	}
+}
+
+// Reserved returns a name validator that rejects given reserved names.
+func Reserved(names ...string) NameValidator {
+	reserved := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		reserved[name] = struct{}{}
+	}
+
+	return func(name string) error {
+		if _, ok := reserved[name]; ok {
+			return rterror.New("name is reserved", name)
+		}
+
+		return nil
+	}
+}
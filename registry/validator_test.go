@@ -0,0 +1,79 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+var ReaderType = reflect.TypeOf((*io.Reader)(nil)).Elem() // nolint: gochecknoglobals
+
+func TestValidatorAssignableTo(test *testing.T) {
+	validator := registry.AssignableTo(ReaderType)
+
+	assert.NoError(test, validator("reader", strings.NewReader("")))
+	assert.Error(test, validator("object", struct{}{}))
+	assert.Error(test, validator("nil", nil))
+}
+
+func TestValidatorAssignableToNil(test *testing.T) {
+	assert.Panics(test, func() { registry.AssignableTo(nil) })
+}
+
+func TestValidatorAssignableToConcreteType(test *testing.T) {
+	validator := registry.AssignableTo(reflect.TypeOf(0))
+
+	assert.NoError(test, validator("int", 5))
+	assert.Error(test, validator("string", "5"))
+}
+
+func TestNameValidatorMatchName(test *testing.T) {
+	validator := registry.MatchName(regexp.MustCompile(`^[a-z.]+$`))
+
+	assert.NoError(test, validator("http.middleware"))
+	assert.Error(test, validator("HTTP"))
+}
+
+func TestNameValidatorMaxLength(test *testing.T) {
+	validator := registry.MaxLength(4)
+
+	assert.NoError(test, validator("json"))
+	assert.NoError(test, validator("żółw"))
+	assert.Error(test, validator("jsons"))
+}
+
+func TestNameValidatorReserved(test *testing.T) {
+	validator := registry.Reserved("default", "all")
+
+	assert.NoError(test, validator("json"))
+	assert.Error(test, validator("default"))
+	assert.Error(test, validator("all"))
+}
+
+func TestNameValidators(test *testing.T) {
+	validator := registry.NameValidators(registry.MaxLength(8), registry.Reserved("default"))
+
+	assert.NoError(test, validator("json"))
+	assert.Error(test, validator("default"))
+	assert.Error(test, validator("very-long-name"))
+	assert.NoError(test, registry.NameValidators()("default"))
+}
@@ -0,0 +1,345 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"net/rpc"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// Client defines a registry client keeping a read-through cache of a server
+// registry. After connection is lost it reconnects in background and
+// resynchronizes the whole cache. It is safe for concurrent use.
+type Client struct {
+	guard         guard.Guard
+	cache         registry.Objects
+	aliases       map[string]string
+	version       uint64
+	rpc           *rpc.Client
+	network       string
+	address       string
+	retryInterval time.Duration
+	watchTimeout  time.Duration
+	listener      Listener
+	errorHandler  ErrorHandler
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+// Dial connects to a server listening on a given network address, loads a
+// full registry snapshot and starts following server changes in background.
+func Dial(network, address string, options ...Option) (*Client, error) {
+	c := &Client{
+		cache:         registry.Objects{},
+		aliases:       map[string]string{},
+		network:       network,
+		address:       address,
+		retryInterval: DefaultRetryInterval,
+		watchTimeout:  DefaultWatchTimeout,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// Close stops following server changes and closes connection.
+func (c *Client) Close() error {
+	select {
+	case <-c.stop:
+		return nil
+	default:
+		close(c.stop)
+	}
+
+	c.disconnect()
+	<-c.done
+
+	return nil
+}
+
+// Get returns registered object by given name. On cache miss it asks server
+// directly if client is connected and caches returned object under a name
+// normalized by server. A given name is remembered as an alias of normalized
+// name, so following lookups with the same name are served from cache.
+func (c *Client) Get(name string) (interface{}, error) {
+	var (
+		object interface{}
+		ok     bool
+		client *rpc.Client
+	)
+
+	c.guard.Read(func() {
+		object, ok = c.lookup(name)
+		client = c.rpc
+	})
+
+	if ok {
+		return object, nil
+	}
+
+	if client == nil {
+		return nil, rterror.New("object was not registered", name)
+	}
+
+	reply := new(GetReply)
+
+	if err := client.Call(ServiceName+".Get", GetArgs{Name: name}, reply); err != nil {
+		return nil, rterror.New("cannot get object", name, err)
+	}
+
+	if !reply.Found {
+		return nil, rterror.New("object was not registered", name)
+	}
+
+	c.guard.Write(func() {
+		if reply.Name != name {
+			c.aliases[name] = reply.Name
+		}
+
+		// Store object only if cache has not already seen newer changes
+		if reply.Version > c.version {
+			c.cache[reply.Name] = reply.Object
+		}
+	})
+
+	return reply.Object, nil
+}
+
+// GetAll returns all cached objects.
+func (c *Client) GetAll() (objects registry.Objects) {
+	c.guard.Read(func() {
+		objects = make(registry.Objects, len(c.cache))
+
+		for name, object := range c.cache {
+			objects[name] = object
+		}
+	})
+
+	return objects
+}
+
+// IsExist returns true if object with given name is cached, otherwise it returns false.
+func (c *Client) IsExist(name string) (ok bool) {
+	c.guard.Read(func() {
+		_, ok = c.lookup(name)
+	})
+
+	return ok
+}
+
+// Version returns server registry version reflected by cache.
+func (c *Client) Version() (version uint64) {
+	c.guard.Read(func() {
+		version = c.version
+	})
+
+	return version
+}
+
+// IsConnected returns true if client is connected to server, otherwise it returns false.
+func (c *Client) IsConnected() (ok bool) {
+	c.guard.Read(func() {
+		ok = c.rpc != nil
+	})
+
+	return ok
+}
+
+// lookup returns cached object by given name or by its alias. Client must be locked.
+func (c *Client) lookup(name string) (object interface{}, ok bool) {
+	if object, ok = c.cache[name]; ok {
+		return object, true
+	}
+
+	if key, found := c.aliases[name]; found {
+		object, ok = c.cache[key]
+	}
+
+	return object, ok
+}
+
+// run follows server changes and reconnects until client is closed.
+func (c *Client) run() {
+	defer close(c.done)
+
+	for {
+		err := c.watch()
+
+		c.disconnect()
+
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		c.report(err)
+
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-time.After(c.retryInterval):
+			}
+
+			if err = c.connect(); err == nil {
+				break
+			}
+
+			c.report(err)
+		}
+	}
+}
+
+// watch applies server changes to cache until an error occurs.
+func (c *Client) watch() error {
+	for {
+		var (
+			client  *rpc.Client
+			version uint64
+		)
+
+		c.guard.Read(func() {
+			client, version = c.rpc, c.version
+		})
+
+		if client == nil {
+			return rterror.New("client was disconnected")
+		}
+
+		reply := new(WatchReply)
+
+		if err := client.Call(ServiceName+".Watch", WatchArgs{Version: version, Timeout: c.watchTimeout}, reply); err != nil {
+			return rterror.New("cannot watch changes", err)
+		}
+
+		if reply.Resync {
+			if err := c.resync(client); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		c.apply(reply.Changes)
+	}
+}
+
+// connect connects to server and resynchronizes cache.
+func (c *Client) connect() error {
+	client, err := rpc.Dial(c.network, c.address)
+
+	if err != nil {
+		return rterror.New("cannot connect to server", c.network, c.address, err)
+	}
+
+	if err = c.resync(client); err != nil {
+		client.Close()
+		return err
+	}
+
+	select {
+	case <-c.stop:
+		client.Close()
+		return rterror.New("client was closed")
+	default:
+	}
+
+	c.guard.Write(func() {
+		c.rpc = client
+	})
+
+	return nil
+}
+
+// disconnect closes current connection.
+func (c *Client) disconnect() {
+	c.guard.Write(func() {
+		if c.rpc != nil {
+			c.rpc.Close()
+			c.rpc = nil
+		}
+	})
+}
+
+// resync replaces cache with a full server registry snapshot.
+func (c *Client) resync(client *rpc.Client) error {
+	reply := new(SnapshotReply)
+
+	if err := client.Call(ServiceName+".Snapshot", SnapshotArgs{}, reply); err != nil {
+		return rterror.New("cannot get snapshot", err)
+	}
+
+	if reply.Objects == nil {
+		reply.Objects = registry.Objects{}
+	}
+
+	c.guard.Write(func() {
+		c.cache = reply.Objects
+		c.version = reply.Version
+	})
+
+	if c.listener != nil {
+		c.listener(nil)
+	}
+
+	return nil
+}
+
+// apply applies given changes to cache.
+func (c *Client) apply(changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	c.guard.Write(func() {
+		for _, change := range changes {
+			switch change.Type {
+			case Set:
+				c.cache[change.Name] = change.Object
+			case Removed:
+				delete(c.cache, change.Name)
+			}
+
+			c.version = change.Version
+		}
+	})
+
+	if c.listener != nil {
+		c.listener(changes)
+	}
+}
+
+// report reports a given error to error handler.
+func (c *Client) report(err error) {
+	if (err != nil) && (c.errorHandler != nil) {
+		c.errorHandler(err)
+	}
+}
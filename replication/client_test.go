@@ -0,0 +1,239 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+	"gitlab.com/tymonx/go-patterns/replication"
+)
+
+type ClientRoute struct {
+	Host string
+	Port int
+}
+
+func init() { // nolint: gochecknoinits
+	if err := registry.RegisterType("replication.route", ClientRoute{}); err != nil {
+		panic(err)
+	}
+}
+
+func Eventually(test *testing.T, condition func() bool) {
+	assert.Eventually(test, condition, 5*time.Second, time.Millisecond)
+}
+
+func TestClientSnapshot(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+
+	server.Sets(registry.Objects{
+		"routeA": ClientRoute{Host: "localhost", Port: 8080},
+		"routeB": "localhost:8081",
+	})
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("tcp", listener.Addr().String())
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	assert.True(test, client.IsConnected())
+	assert.Equal(test, server.GetAll(), client.GetAll())
+	assert.Equal(test, server.Version(), client.Version())
+
+	object, err := client.Get("routeA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, ClientRoute{Host: "localhost", Port: 8080}, object)
+}
+
+func TestClientUnixSocket(test *testing.T) {
+	dir, err := ioutil.TempDir("", "replication")
+
+	assert.NoError(test, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "registry.sock")
+	listener, err := net.Listen("unix", path)
+
+	assert.NoError(test, err)
+
+	server := replication.NewServer()
+	server.Set("route", "value")
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("unix", path)
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	assert.True(test, client.IsExist("route"))
+}
+
+func TestClientChanges(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+	changes := make(chan []replication.Change, 16)
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("tcp", listener.Addr().String(),
+		replication.WithWatchTimeout(10*time.Millisecond),
+		replication.WithListener(func(c []replication.Change) {
+			changes <- c
+		}),
+	)
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	assert.Nil(test, <-changes)
+
+	server.Set("route", "valueA")
+
+	assert.Equal(test, []replication.Change{
+		{Version: 1, Type: replication.Set, Name: "route", Object: "valueA"},
+	}, <-changes)
+
+	server.Remove("route")
+
+	assert.Equal(test, []replication.Change{
+		{Version: 2, Type: replication.Removed, Name: "route"},
+	}, <-changes)
+
+	assert.False(test, client.IsExist("route"))
+	assert.Equal(test, uint64(2), client.Version())
+}
+
+func TestClientReadThrough(test *testing.T) {
+	server := replication.NewServer(registry.WithNormalizer(registry.FoldCase))
+	listener := Listen(test)
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("tcp", listener.Addr().String(), replication.WithWatchTimeout(time.Minute))
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	server.Set("route", "value")
+
+	object, err := client.Get("ROUTE")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", object)
+	assert.True(test, client.IsExist("ROUTE"))
+
+	_, err = client.Get("missing")
+	assert.Error(test, err)
+
+	assert.NoError(test, client.Close())
+
+	object, err = client.Get("ROUTE")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "value", object)
+}
+
+func TestClientReconnect(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+	address := listener.Addr().String()
+	errs := make(chan error, 16)
+
+	server.Set("routeA", "valueA")
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("tcp", address,
+		replication.WithRetryInterval(time.Millisecond),
+		replication.WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	assert.NoError(test, server.Close())
+	assert.Error(test, <-errs)
+
+	Eventually(test, func() bool { return !client.IsConnected() })
+
+	_, err = client.Get("missing")
+	assert.Error(test, err)
+
+	object, err := client.Get("routeA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "valueA", object)
+
+	restarted := replication.NewServer()
+	restarted.Set("routeB", "valueB")
+
+	listener, err = net.Listen("tcp", address)
+
+	assert.NoError(test, err)
+
+	Serve(test, restarted, listener)
+
+	Eventually(test, client.IsConnected)
+	Eventually(test, func() bool {
+		return assert.ObjectsAreEqual(registry.Objects{"routeB": "valueB"}, client.GetAll())
+	})
+}
+
+func TestClientDialError(test *testing.T) {
+	listener := Listen(test)
+	address := listener.Addr().String()
+
+	assert.NoError(test, listener.Close())
+
+	_, err := replication.Dial("tcp", address)
+	assert.Error(test, err)
+}
+
+func TestClientClose(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+
+	Serve(test, server, listener)
+
+	client, err := replication.Dial("tcp", listener.Addr().String())
+
+	assert.NoError(test, err)
+	assert.NoError(test, client.Close())
+	assert.NoError(test, client.Close())
+	assert.False(test, client.IsConnected())
+}
@@ -0,0 +1,22 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replication implements a registry server and client communicating
+// with the net/rpc package, typically over a Unix socket or a TCP loopback.
+// Clients keep a read-through cache of the server registry, follow its change
+// stream and resynchronize it after reconnecting.
+//
+// Registered objects are transferred with the gob package, objects of
+// non-basic types must be registered with the registry.RegisterType function.
+package replication
@@ -0,0 +1,65 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"time"
+)
+
+// DefaultRetryInterval defines a default interval between reconnection attempts.
+const DefaultRetryInterval = time.Second
+
+// DefaultWatchTimeout defines a default time a single Watch RPC call waits for changes.
+const DefaultWatchTimeout = 30 * time.Second
+
+// Listener defines a function called with changes applied to client cache.
+// A full resynchronization is reported with a nil changes list.
+type Listener func(changes []Change)
+
+// ErrorHandler defines a function called when client loses connection or
+// fails to reconnect.
+type ErrorHandler func(err error)
+
+// Option defines a client option used to configure a client object.
+type Option func(c *Client)
+
+// WithRetryInterval sets an interval between reconnection attempts.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.retryInterval = interval
+	}
+}
+
+// WithWatchTimeout sets a time a single Watch RPC call waits for changes.
+func WithWatchTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.watchTimeout = timeout
+	}
+}
+
+// WithListener sets a listener called with changes applied to client cache.
+func WithListener(listener Listener) Option {
+	return func(c *Client) {
+		c.listener = listener
+	}
+}
+
+// WithErrorHandler sets an error handler called when client loses connection
+// or fails to reconnect.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *Client) {
+		c.errorHandler = handler
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"time"
+
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// ServiceName defines a name of registry service registered with RPC server.
+const ServiceName = "Registry"
+
+// ChangeType defines a type of registry change.
+type ChangeType int
+
+// Registry change types.
+const (
+	Set ChangeType = iota
+	Removed
+)
+
+// Change defines a registry change.
+type Change struct {
+	Version uint64
+	Type    ChangeType
+	Name    string
+	Object  interface{}
+}
+
+// SnapshotArgs defines arguments of the Snapshot RPC method.
+type SnapshotArgs struct{}
+
+// SnapshotReply defines reply of the Snapshot RPC method.
+type SnapshotReply struct {
+	Version uint64
+	Objects registry.Objects
+}
+
+// GetArgs defines arguments of the Get RPC method.
+type GetArgs struct {
+	Name string
+}
+
+// GetReply defines reply of the Get RPC method.
+type GetReply struct {
+	Version uint64
+	Name    string
+	Found   bool
+	Object  interface{}
+}
+
+// WatchArgs defines arguments of the Watch RPC method.
+type WatchArgs struct {
+	Version uint64
+	Timeout time.Duration
+}
+
+// WatchReply defines reply of the Watch RPC method.
+type WatchReply struct {
+	Version uint64
+	Resync  bool
+	Changes []Change
+}
+
+// String returns change type name.
+func (t ChangeType) String() string {
+	switch t {
+	case Set:
+		return "set"
+	case Removed:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// HistorySize defines a number of recent changes kept by server. Clients
+// lagging behind more changes are resynchronized with a full snapshot.
+const HistorySize = 1024
+
+// MaxWatchTimeout defines a maximum time a Watch RPC call waits for changes.
+const MaxWatchTimeout = time.Minute
+
+// Server defines a primary registry served to clients. All mutations must be
+// done through server methods. It is safe for concurrent use.
+type Server struct {
+	guard        guard.Guard
+	registry     *registry.Registry
+	version      uint64
+	history      []Change
+	notify       chan struct{}
+	closed       chan struct{}
+	close        sync.Once
+	serving      sync.WaitGroup
+	networkGuard guard.Guard
+	listeners    map[net.Listener]struct{}
+	conns        map[net.Conn]struct{}
+}
+
+// service defines RPC methods exposed by server.
+type service struct {
+	server *Server
+}
+
+// NewServer creates a new server object. Given registry options are applied
+// to the served registry.
+func NewServer(options ...registry.Option) *Server {
+	return &Server{
+		registry:  registry.New(options...),
+		notify:    make(chan struct{}),
+		closed:    make(chan struct{}),
+		listeners: map[net.Listener]struct{}{},
+		conns:     map[net.Conn]struct{}{},
+	}
+}
+
+// Serve accepts connections on a given listener and serves RPC requests until
+// server is closed. It always returns a non-nil error.
+func (s *Server) Serve(listener net.Listener) error {
+	server := rpc.NewServer()
+
+	if err := server.RegisterName(ServiceName, &service{server: s}); err != nil {
+		return rterror.New("cannot register service", err)
+	}
+
+	if !s.track(listener, true) {
+		listener.Close()
+		return rterror.New("server was closed")
+	}
+
+	defer s.track(listener, false)
+
+	for {
+		conn, err := listener.Accept()
+
+		if err != nil {
+			select {
+			case <-s.closed:
+				return rterror.New("server was closed")
+			default:
+				return rterror.New("cannot accept connection", err)
+			}
+		}
+
+		if !s.trackConn(conn, true) {
+			conn.Close()
+			continue
+		}
+
+		s.serving.Add(1)
+
+		go func() {
+			defer s.serving.Done()
+			defer s.trackConn(conn, false)
+
+			server.ServeConn(conn)
+		}()
+	}
+}
+
+// Close closes all listeners and connections and wakes up all watching clients.
+func (s *Server) Close() error {
+	s.close.Do(func() {
+		close(s.closed)
+
+		s.networkGuard.Write(func() {
+			for listener := range s.listeners {
+				listener.Close()
+			}
+
+			for conn := range s.conns {
+				conn.Close()
+			}
+		})
+	})
+
+	s.serving.Wait()
+
+	return nil
+}
+
+// Add adds an object with a given unique id to registry.
+func (s *Server) Add(name string, object interface{}) (err error) {
+	s.guard.Write(func() {
+		if err = s.registry.Add(name, object); err == nil {
+			s.record(Set, name, object)
+		}
+	})
+
+	return err
+}
+
+// Set sets an object with a given unique id to registry.
+// It panics if name or object does not pass registry constraints.
+func (s *Server) Set(name string, object interface{}) {
+	s.Sets(registry.Objects{name: object})
+}
+
+// Sets sets objects with given unique ids to registry.
+// It panics without setting anything if any name or object does not pass registry constraints.
+func (s *Server) Sets(objects registry.Objects) {
+	s.guard.Write(func() {
+		s.registry.Sets(objects)
+
+		for name, object := range objects {
+			s.record(Set, name, object)
+		}
+	})
+}
+
+// Remove removes registered object.
+func (s *Server) Remove(name string) {
+	s.Removes([]string{name})
+}
+
+// Removes removes registered objects.
+func (s *Server) Removes(names []string) {
+	s.guard.Write(func() {
+		for _, name := range names {
+			if s.registry.IsExist(name) {
+				s.registry.Remove(name)
+				s.record(Removed, name, nil)
+			}
+		}
+	})
+}
+
+// Get returns registered object by given name.
+func (s *Server) Get(name string) (object interface{}, err error) {
+	s.guard.Read(func() {
+		object, err = s.registry.Get(name)
+	})
+
+	return object, err
+}
+
+// GetAll returns all registered objects.
+func (s *Server) GetAll() (objects registry.Objects) {
+	s.guard.Read(func() {
+		objects = s.registry.GetAll()
+	})
+
+	return objects
+}
+
+// Version returns current registry version incremented by every change.
+func (s *Server) Version() (version uint64) {
+	s.guard.Read(func() {
+		version = s.version
+	})
+
+	return version
+}
+
+// record records a change and wakes up all watching clients.
+func (s *Server) record(kind ChangeType, name string, object interface{}) {
+	if key, err := s.registry.Key(name); err == nil {
+		name = key
+	}
+
+	s.version++
+
+	s.history = append(s.history, Change{
+		Version: s.version,
+		Type:    kind,
+		Name:    name,
+		Object:  object,
+	})
+
+	if len(s.history) > HistorySize {
+		s.history = append([]Change(nil), s.history[len(s.history)-HistorySize:]...)
+	}
+
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// track adds or removes a given listener from tracked listeners.
+func (s *Server) track(listener net.Listener, add bool) (ok bool) {
+	s.networkGuard.Write(func() {
+		if !add {
+			delete(s.listeners, listener)
+			return
+		}
+
+		select {
+		case <-s.closed:
+			return
+		default:
+			s.listeners[listener] = struct{}{}
+			ok = true
+		}
+	})
+
+	return ok
+}
+
+// trackConn adds or removes a given connection from tracked connections.
+func (s *Server) trackConn(conn net.Conn, add bool) (ok bool) {
+	s.networkGuard.Write(func() {
+		if !add {
+			delete(s.conns, conn)
+			return
+		}
+
+		select {
+		case <-s.closed:
+			return
+		default:
+			s.conns[conn] = struct{}{}
+			ok = true
+		}
+	})
+
+	return ok
+}
+
+// Snapshot returns all registered objects with current registry version.
+func (s *service) Snapshot(_ SnapshotArgs, reply *SnapshotReply) error {
+	s.server.guard.Read(func() {
+		reply.Version = s.server.version
+		reply.Objects = s.server.registry.GetAll()
+	})
+
+	return nil
+}
+
+// Get returns registered object by given name with current registry version.
+func (s *service) Get(args GetArgs, reply *GetReply) error {
+	s.server.guard.Read(func() {
+		object, err := s.server.registry.Get(args.Name)
+
+		reply.Name, _ = s.server.registry.Key(args.Name)
+		reply.Version = s.server.version
+		reply.Found = err == nil
+		reply.Object = object
+	})
+
+	return nil
+}
+
+// Watch waits for changes after a given version and returns them. It returns
+// no changes if timeout elapsed and requests a resync if changes after a given
+// version are no longer available.
+func (s *service) Watch(args WatchArgs, reply *WatchReply) error {
+	timeout := args.Timeout
+
+	if (timeout <= 0) || (timeout > MaxWatchTimeout) {
+		timeout = MaxWatchTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		var notify chan struct{}
+
+		s.server.guard.Read(func() {
+			reply.Version = s.server.version
+
+			if args.Version >= s.server.version {
+				notify = s.server.notify
+				return
+			}
+
+			if (len(s.server.history) == 0) || (s.server.history[0].Version > args.Version+1) {
+				reply.Resync = true
+				return
+			}
+
+			for _, change := range s.server.history {
+				if change.Version > args.Version {
+					reply.Changes = append(reply.Changes, change)
+				}
+			}
+		})
+
+		if notify == nil {
+			return nil
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return nil
+		case <-s.server.closed:
+			return rterror.New("server was closed")
+		}
+	}
+}
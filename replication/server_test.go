@@ -0,0 +1,154 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication_test
+
+import (
+	"net"
+	"net/rpc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+	"gitlab.com/tymonx/go-patterns/replication"
+)
+
+func Listen(test *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return listener
+}
+
+func Serve(test *testing.T, server *replication.Server, listener net.Listener) {
+	go server.Serve(listener) // nolint: errcheck
+
+	test.Cleanup(func() {
+		server.Close()
+	})
+}
+
+func TestServerMutations(test *testing.T) {
+	server := replication.NewServer(registry.WithNormalizer(registry.FoldCase))
+
+	assert.NoError(test, server.Add("ObjectA", "valueA"))
+	assert.Error(test, server.Add("objecta", "valueA"))
+	server.Set("objectB", "valueB")
+	server.Sets(registry.Objects{"objectC": "valueC"})
+	server.Remove("objectB")
+	server.Removes([]string{"objectD"})
+
+	assert.Equal(test, uint64(4), server.Version())
+	assert.Equal(test, registry.Objects{"objecta": "valueA", "objectc": "valueC"}, server.GetAll())
+
+	object, err := server.Get("OBJECTA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "valueA", object)
+}
+
+func TestServerWatch(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+
+	Serve(test, server, listener)
+
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	reply := new(replication.WatchReply)
+
+	assert.NoError(test, client.Call(replication.ServiceName+".Watch", replication.WatchArgs{
+		Timeout: time.Millisecond,
+	}, reply))
+
+	assert.Empty(test, reply.Changes)
+	assert.Zero(test, reply.Version)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		server.Set("object", "value")
+	}()
+
+	reply = new(replication.WatchReply)
+
+	assert.NoError(test, client.Call(replication.ServiceName+".Watch", replication.WatchArgs{
+		Timeout: 5 * time.Second,
+	}, reply))
+
+	assert.Equal(test, uint64(1), reply.Version)
+	assert.Equal(test, []replication.Change{
+		{Version: 1, Type: replication.Set, Name: "object", Object: "value"},
+	}, reply.Changes)
+}
+
+func TestServerWatchResync(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+
+	Serve(test, server, listener)
+
+	for i := 0; i <= replication.HistorySize; i++ {
+		server.Set("object", i)
+	}
+
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	reply := new(replication.WatchReply)
+
+	assert.NoError(test, client.Call(replication.ServiceName+".Watch", replication.WatchArgs{}, reply))
+	assert.True(test, reply.Resync)
+	assert.Empty(test, reply.Changes)
+}
+
+func TestServerClose(test *testing.T) {
+	server := replication.NewServer()
+	listener := Listen(test)
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+
+	assert.NoError(test, err)
+
+	defer client.Close()
+
+	call := client.Go(replication.ServiceName+".Watch", replication.WatchArgs{}, new(replication.WatchReply), nil)
+
+	assert.NoError(test, server.Close())
+	assert.NoError(test, server.Close())
+	assert.Error(test, <-errs)
+	assert.Error(test, (<-call.Done).Error)
+	assert.Error(test, server.Serve(Listen(test)))
+}
+
+func TestChangeTypeString(test *testing.T) {
+	assert.Equal(test, "set", replication.Set.String())
+	assert.Equal(test, "removed", replication.Removed.String())
+	assert.Equal(test, "unknown", replication.ChangeType(-1).String())
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// DefaultCompactThreshold defines a default number of log records after which
+// log is compacted into a snapshot.
+const DefaultCompactThreshold = 1024
+
+// Option defines a durable registry option used to configure a durable registry object.
+type Option func(r *Registry)
+
+// WithCodec sets a codec used to encode registered objects in log records and snapshots.
+// By default the registry.JSON codec is used.
+func WithCodec(codec registry.Codec) Option {
+	return func(r *Registry) {
+		r.codec = codec
+	}
+}
+
+// WithCompactThreshold sets a number of log records after which log is
+// compacted into a snapshot. Zero or negative value disables automatic compaction.
+func WithCompactThreshold(threshold int) Option {
+	return func(r *Registry) {
+		r.threshold = threshold
+	}
+}
+
+// WithSync enables or disables syncing log file to a storage after every
+// appended record. It is enabled by default.
+func WithSync(enabled bool) Option {
+	return func(r *Registry) {
+		r.sync = enabled
+	}
+}
+
+// WithRegistryOptions sets options used to create the underlying registry.
+func WithRegistryOptions(options ...registry.Option) Option {
+	return func(r *Registry) {
+		r.options = append(r.options, options...)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// headerSize defines size of a record header with payload length and payload checksum.
+const headerSize = 8
+
+// maxPayloadSize defines a maximum size of a record payload.
+const maxPayloadSize = 1 << 30
+
+// Operation defines a logged registry mutation.
+type Operation byte
+
+// Logged registry mutations.
+const (
+	OperationSet Operation = iota + 1
+	OperationRemove
+)
+
+var gTable = crc32.MakeTable(crc32.Castagnoli) // nolint: gochecknoglobals
+
+// record defines a logged registry mutation.
+type record struct {
+	operation Operation
+	objects   registry.Objects
+	names     []string
+}
+
+// encodeRecord returns encoded record with a header.
+func encodeRecord(rec *record, codec registry.Codec) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(make([]byte, headerSize))
+	buffer.WriteByte(byte(rec.operation))
+
+	switch rec.operation {
+	case OperationSet:
+		if err := codec.Encode(&buffer, rec.objects); err != nil {
+			return nil, err
+		}
+	case OperationRemove:
+		if err := json.NewEncoder(&buffer).Encode(rec.names); err != nil {
+			return nil, rterror.New("cannot encode names", err)
+		}
+	default:
+		return nil, rterror.New("unknown operation", int(rec.operation))
+	}
+
+	data := buffer.Bytes()
+	payload := data[headerSize:]
+
+	binary.BigEndian.PutUint32(data[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(data[4:8], crc32.Checksum(payload, gTable))
+
+	return data, nil
+}
+
+// readRecord reads a single record payload. It returns io.EOF if there are
+// no more records and an error if record was torn or corrupted.
+func readRecord(reader *bufio.Reader) ([]byte, error) {
+	header := make([]byte, headerSize)
+
+	if n, err := io.ReadFull(reader, header); err != nil {
+		if n == 0 && err == io.EOF {
+			return nil, io.EOF
+		}
+
+		return nil, rterror.New("torn record header", err)
+	}
+
+	size := binary.BigEndian.Uint32(header[0:4])
+
+	if size == 0 || size > maxPayloadSize {
+		return nil, rterror.New("invalid record size", int(size))
+	}
+
+	payload := make([]byte, size)
+
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, rterror.New("torn record payload", err)
+	}
+
+	if crc32.Checksum(payload, gTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, rterror.New("invalid record checksum")
+	}
+
+	return payload, nil
+}
+
+// decodeRecord returns decoded record from a given record payload.
+func decodeRecord(payload []byte, codec registry.Codec) (*record, error) {
+	rec := &record{operation: Operation(payload[0])}
+
+	switch rec.operation {
+	case OperationSet:
+		objects, err := codec.Decode(bytes.NewReader(payload[1:]))
+
+		if err != nil {
+			return nil, err
+		}
+
+		rec.objects = objects
+	case OperationRemove:
+		if err := json.Unmarshal(payload[1:], &rec.names); err != nil {
+			return nil, rterror.New("cannot decode names", err)
+		}
+	default:
+		return nil, rterror.New("unknown operation", int(rec.operation))
+	}
+
+	return rec, nil
+}
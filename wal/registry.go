@@ -0,0 +1,388 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// LogFile defines a name of log file in a durable registry directory.
+const LogFile = "registry.wal"
+
+// SnapshotFile defines a name of snapshot file in a durable registry directory.
+const SnapshotFile = "registry.snapshot"
+
+// DirMode defines permissions used when creating a durable registry directory.
+const DirMode = 0o700
+
+// Registry defines a durable registry. Every mutation is appended to a log
+// file before it is applied to registry. On open, registry is restored from
+// a snapshot file and log records appended after it. Torn or corrupted
+// records at the end of log, left by a crash during write, are discarded.
+// It is safe for concurrent use.
+type Registry struct {
+	guard     guard.Guard
+	registry  *registry.Registry
+	dir       string
+	log       *os.File
+	codec     registry.Codec
+	threshold int
+	records   int
+	sync      bool
+	failed    error
+	options   []registry.Option
+}
+
+// Open opens a durable registry stored in a given directory. The directory is
+// created if it does not exist.
+func Open(dir string, options ...Option) (*Registry, error) {
+	r := &Registry{
+		dir:       dir,
+		codec:     registry.JSON{},
+		threshold: DefaultCompactThreshold,
+		sync:      true,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	r.registry = registry.New(r.options...)
+
+	if err := os.MkdirAll(dir, DirMode); err != nil {
+		return nil, rterror.New("cannot create directory", dir, err)
+	}
+
+	if err := r.restore(); err != nil {
+		return nil, err
+	}
+
+	log, err := os.OpenFile(r.logPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, registry.FileMode)
+
+	if err != nil {
+		return nil, rterror.New("cannot open log file", r.logPath(), err)
+	}
+
+	r.log = log
+
+	return r, nil
+}
+
+// Close closes log file.
+func (r *Registry) Close() (err error) {
+	r.guard.Write(func() {
+		if r.log == nil {
+			return
+		}
+
+		if err = r.log.Close(); err != nil {
+			err = rterror.New("cannot close log file", r.logPath(), err)
+		}
+
+		r.log = nil
+	})
+
+	return err
+}
+
+// Add adds an object with a given unique id to registry.
+func (r *Registry) Add(name string, object interface{}) (err error) {
+	r.guard.Write(func() {
+		if r.registry.IsExist(name) {
+			err = rterror.New("object was already registered", name)
+			return
+		}
+
+		if err = r.set(registry.Objects{name: object}); err != nil {
+			return
+		}
+
+		if err = r.registry.Add(name, object); err == nil {
+			r.compactIfNeeded()
+		}
+	})
+
+	return err
+}
+
+// Set sets an object with a given unique id to registry.
+func (r *Registry) Set(name string, object interface{}) error {
+	return r.Sets(registry.Objects{name: object})
+}
+
+// Sets sets objects with given unique ids to registry.
+func (r *Registry) Sets(objects registry.Objects) (err error) {
+	r.guard.Write(func() {
+		if err = r.set(objects); err != nil {
+			return
+		}
+
+		if err = r.registry.TrySets(objects); err == nil {
+			r.compactIfNeeded()
+		}
+	})
+
+	return err
+}
+
+// Remove removes registered object.
+func (r *Registry) Remove(name string) error {
+	return r.Removes([]string{name})
+}
+
+// Removes removes registered objects.
+func (r *Registry) Removes(names []string) (err error) {
+	r.guard.Write(func() {
+		if err = r.append(&record{operation: OperationRemove, names: names}); err != nil {
+			return
+		}
+
+		r.registry.Removes(names)
+		r.compactIfNeeded()
+	})
+
+	return err
+}
+
+// Get returns registered object by given name.
+func (r *Registry) Get(name string) (object interface{}, err error) {
+	r.guard.Read(func() {
+		object, err = r.registry.Get(name)
+	})
+
+	return object, err
+}
+
+// GetAll returns all registered objects.
+func (r *Registry) GetAll() (objects registry.Objects) {
+	r.guard.Read(func() {
+		objects = r.registry.GetAll()
+	})
+
+	return objects
+}
+
+// IsExist returns true if object with given name was registered, otherwise it returns false.
+func (r *Registry) IsExist(name string) (value bool) {
+	r.guard.Read(func() {
+		value = r.registry.IsExist(name)
+	})
+
+	return value
+}
+
+// Compact writes all registered objects to a snapshot file and truncates log file.
+func (r *Registry) Compact() (err error) {
+	r.guard.Write(func() {
+		err = r.compact()
+	})
+
+	return err
+}
+
+// set validates and appends given objects to log file.
+func (r *Registry) set(objects registry.Objects) error {
+	if err := r.validate(objects); err != nil {
+		return err
+	}
+
+	return r.append(&record{operation: OperationSet, objects: objects})
+}
+
+// validate returns an error if any name or object does not pass registry
+// constraints or if different names are normalized to the same key.
+func (r *Registry) validate(objects registry.Objects) error {
+	return r.registry.ValidateObjects(objects)
+}
+
+// append appends a given record to log file. If a record cannot be written
+// or synced, log file is truncated back to its previous size, so a partial
+// record does not hide records appended after it on replay. If that fails
+// too, log file is marked as failed and no more records are appended.
+func (r *Registry) append(rec *record) error {
+	if r.log == nil {
+		return rterror.New("log file was closed", r.logPath())
+	}
+
+	if r.failed != nil {
+		return r.failed
+	}
+
+	data, err := encodeRecord(rec, r.codec)
+
+	if err != nil {
+		return rterror.New("cannot encode record", err)
+	}
+
+	info, err := r.log.Stat()
+
+	if err != nil {
+		return rterror.New("cannot stat log file", r.logPath(), err)
+	}
+
+	if err = r.write(data); err != nil {
+		if truncateErr := r.log.Truncate(info.Size()); truncateErr != nil {
+			r.failed = rterror.New("log file failed", r.logPath(), truncateErr)
+		}
+
+		return err
+	}
+
+	r.records++
+
+	return nil
+}
+
+// write writes given data to log file and syncs it if needed.
+func (r *Registry) write(data []byte) error {
+	if _, err := r.log.Write(data); err != nil {
+		return rterror.New("cannot write log file", r.logPath(), err)
+	}
+
+	if r.sync {
+		if err := r.log.Sync(); err != nil {
+			return rterror.New("cannot sync log file", r.logPath(), err)
+		}
+	}
+
+	return nil
+}
+
+// compactIfNeeded compacts log file if number of log records reached threshold.
+// It must be called after logged mutation was applied to registry.
+func (r *Registry) compactIfNeeded() {
+	if (r.threshold > 0) && (r.records >= r.threshold) {
+		// Records are already durable, a failed compaction is retried later
+		_ = r.compact()
+	}
+}
+
+// compact writes all registered objects to a snapshot file and truncates log file.
+// A crash between both steps is safe because log records are idempotent.
+func (r *Registry) compact() error {
+	if r.log == nil {
+		return rterror.New("log file was closed", r.logPath())
+	}
+
+	if err := r.registry.WriteFile(r.snapshotPath(), r.codec); err != nil {
+		return err
+	}
+
+	if err := r.log.Truncate(0); err != nil {
+		return rterror.New("cannot truncate log file", r.logPath(), err)
+	}
+
+	if r.sync {
+		if err := r.log.Sync(); err != nil {
+			return rterror.New("cannot sync log file", r.logPath(), err)
+		}
+	}
+
+	// Snapshot holds all registered objects, a partial record left by
+	// a failed append was truncated with the rest of log
+	r.records = 0
+	r.failed = nil
+
+	return nil
+}
+
+// restore restores registry from a snapshot file and log file.
+func (r *Registry) restore() error {
+	if _, err := os.Stat(r.snapshotPath()); err == nil {
+		if err = r.registry.ReadFile(r.snapshotPath(), r.codec); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return rterror.New("cannot stat snapshot file", r.snapshotPath(), err)
+	}
+
+	file, err := os.Open(r.logPath())
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return rterror.New("cannot open log file", r.logPath(), err)
+	}
+
+	defer file.Close()
+
+	offset, torn, err := r.replay(bufio.NewReader(file))
+
+	if err != nil {
+		return err
+	}
+
+	if torn {
+		if err = os.Truncate(r.logPath(), offset); err != nil {
+			return rterror.New("cannot truncate log file", r.logPath(), err)
+		}
+	}
+
+	return nil
+}
+
+// replay applies log records to registry. It returns an offset after the last
+// valid record and true if a torn or corrupted record was found after it.
+func (r *Registry) replay(reader *bufio.Reader) (offset int64, torn bool, err error) {
+	for {
+		payload, readErr := readRecord(reader)
+
+		if readErr == io.EOF {
+			return offset, false, nil
+		}
+
+		if readErr != nil {
+			return offset, true, nil
+		}
+
+		rec, decodeErr := decodeRecord(payload, r.codec)
+
+		if decodeErr != nil {
+			return offset, false, rterror.New("cannot replay log file", r.logPath(), decodeErr)
+		}
+
+		switch rec.operation {
+		case OperationSet:
+			if err = r.registry.TrySets(rec.objects); err != nil {
+				return offset, false, rterror.New("cannot replay log file", r.logPath(), err)
+			}
+
+		case OperationRemove:
+			r.registry.Removes(rec.names)
+		}
+
+		offset += int64(headerSize + len(payload))
+		r.records++
+	}
+}
+
+// logPath returns path to log file.
+func (r *Registry) logPath() string {
+	return filepath.Join(r.dir, LogFile)
+}
+
+// snapshotPath returns path to snapshot file.
+func (r *Registry) snapshotPath() string {
+	return filepath.Join(r.dir, SnapshotFile)
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+	"gitlab.com/tymonx/go-patterns/wal"
+)
+
+func TempDir(test *testing.T) string {
+	path, err := ioutil.TempDir("", "wal")
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	return path
+}
+
+func Open(test *testing.T, dir string, options ...wal.Option) *wal.Registry {
+	r, err := wal.Open(dir, options...)
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		r.Close()
+	})
+
+	return r
+}
+
+func FileSize(test *testing.T, path string) int64 {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return info.Size()
+}
+
+func TestRegistryReplay(test *testing.T) {
+	dir := TempDir(test)
+	r := Open(test, dir)
+
+	assert.NoError(test, r.Add("objectA", "valueA"))
+	assert.Error(test, r.Add("objectA", "valueA"))
+	assert.NoError(test, r.Set("objectB", "valueB"))
+	assert.NoError(test, r.Sets(registry.Objects{"objectC": "valueC", "objectD": "valueD"}))
+	assert.NoError(test, r.Remove("objectC"))
+	assert.NoError(test, r.Close())
+	assert.NoError(test, r.Close())
+	assert.Error(test, r.Set("objectE", "valueE"))
+
+	restored := Open(test, dir)
+
+	assert.Equal(test, registry.Objects{
+		"objectA": "valueA",
+		"objectB": "valueB",
+		"objectD": "valueD",
+	}, restored.GetAll())
+
+	object, err := restored.Get("objectA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, "valueA", object)
+	assert.True(test, restored.IsExist("objectD"))
+}
+
+func TestRegistryTornWrite(test *testing.T) {
+	dir := TempDir(test)
+	log := filepath.Join(dir, wal.LogFile)
+	r := Open(test, dir)
+
+	assert.NoError(test, r.Set("objectA", "valueA"))
+
+	size := FileSize(test, log)
+
+	assert.NoError(test, r.Set("objectB", "valueB"))
+	assert.NoError(test, r.Close())
+
+	// Simulate a crash in the middle of writing the last record
+	assert.NoError(test, os.Truncate(log, FileSize(test, log)-3))
+
+	restored := Open(test, dir)
+
+	assert.Equal(test, registry.Objects{"objectA": "valueA"}, restored.GetAll())
+	assert.Equal(test, size, FileSize(test, log))
+
+	assert.NoError(test, restored.Set("objectC", "valueC"))
+	assert.NoError(test, restored.Close())
+
+	assert.Equal(test, registry.Objects{
+		"objectA": "valueA",
+		"objectC": "valueC",
+	}, Open(test, dir).GetAll())
+}
+
+func TestRegistryCorruptedRecord(test *testing.T) {
+	dir := TempDir(test)
+	log := filepath.Join(dir, wal.LogFile)
+	r := Open(test, dir)
+
+	assert.NoError(test, r.Set("objectA", "valueA"))
+	assert.NoError(test, r.Set("objectB", "valueB"))
+	assert.NoError(test, r.Close())
+
+	data, err := ioutil.ReadFile(log)
+
+	assert.NoError(test, err)
+
+	// Simulate a partially persisted last record
+	data[len(data)-2] ^= 0xFF
+
+	assert.NoError(test, ioutil.WriteFile(log, data, 0o600))
+	assert.Equal(test, registry.Objects{"objectA": "valueA"}, Open(test, dir).GetAll())
+}
+
+func TestRegistryGarbageHeader(test *testing.T) {
+	dir := TempDir(test)
+	log := filepath.Join(dir, wal.LogFile)
+	r := Open(test, dir)
+
+	assert.NoError(test, r.Set("objectA", "valueA"))
+	assert.NoError(test, r.Close())
+
+	file, err := os.OpenFile(log, os.O_WRONLY|os.O_APPEND, 0o600)
+
+	assert.NoError(test, err)
+
+	_, err = file.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0, 1})
+
+	assert.NoError(test, err)
+	assert.NoError(test, file.Close())
+	assert.Equal(test, registry.Objects{"objectA": "valueA"}, Open(test, dir).GetAll())
+}
+
+func TestRegistryCompact(test *testing.T) {
+	dir := TempDir(test)
+	log := filepath.Join(dir, wal.LogFile)
+	r := Open(test, dir, wal.WithCompactThreshold(3), wal.WithSync(false))
+
+	assert.NoError(test, r.Set("objectA", "valueA"))
+	assert.NoError(test, r.Set("objectB", "valueB"))
+	assert.NotZero(test, FileSize(test, log))
+	assert.NoError(test, r.Remove("objectA"))
+	assert.Zero(test, FileSize(test, log))
+	assert.NoError(test, r.Set("objectC", "valueC"))
+	assert.NoError(test, r.Close())
+
+	assert.Equal(test, registry.Objects{
+		"objectB": "valueB",
+		"objectC": "valueC",
+	}, Open(test, dir).GetAll())
+}
+
+func TestRegistryCrashDuringCompact(test *testing.T) {
+	dir := TempDir(test)
+	log := filepath.Join(dir, wal.LogFile)
+	r := Open(test, dir, wal.WithCompactThreshold(0))
+
+	assert.NoError(test, r.Add("objectA", "valueA"))
+	assert.NoError(test, r.Set("objectB", "valueB"))
+	assert.NoError(test, r.Remove("objectB"))
+
+	data, err := ioutil.ReadFile(log)
+
+	assert.NoError(test, err)
+	assert.NoError(test, r.Compact())
+	assert.NoError(test, r.Close())
+
+	// Simulate a crash after writing snapshot but before truncating log
+	assert.NoError(test, ioutil.WriteFile(log, data, 0o600))
+
+	restored := Open(test, dir)
+
+	assert.Equal(test, registry.Objects{"objectA": "valueA"}, restored.GetAll())
+	assert.NoError(test, restored.Close())
+	assert.Error(test, restored.Compact())
+}
+
+func TestRegistryOptions(test *testing.T) {
+	dir := TempDir(test)
+	r := Open(test, dir,
+		wal.WithCodec(registry.Gob{}),
+		wal.WithRegistryOptions(registry.WithNormalizer(registry.FoldCase)),
+	)
+
+	assert.NoError(test, r.Set("ObjectA", 5))
+	assert.NoError(test, r.Compact())
+	assert.NoError(test, r.Set("ObjectB", 6))
+	assert.NoError(test, r.Close())
+
+	restored := Open(test, dir,
+		wal.WithCodec(registry.Gob{}),
+		wal.WithRegistryOptions(registry.WithNormalizer(registry.FoldCase)),
+	)
+
+	assert.Equal(test, registry.Objects{"objecta": 5, "objectb": 6}, restored.GetAll())
+}
+
+func TestRegistryConstraints(test *testing.T) {
+	dir := TempDir(test)
+	r := Open(test, dir, wal.WithRegistryOptions(registry.WithNameValidator(registry.Reserved("default"))))
+
+	assert.Error(test, r.Set("default", "value"))
+	assert.Error(test, r.Add("default", "value"))
+	assert.NoError(test, r.Set("object", "value"))
+	assert.NoError(test, r.Close())
+
+	_, err := wal.Open(dir, wal.WithRegistryOptions(registry.WithNameValidator(registry.Reserved("object"))))
+
+	assert.Error(test, err)
+}
+
+func TestRegistryNormalizedCollision(test *testing.T) {
+	dir := TempDir(test)
+	option := wal.WithRegistryOptions(registry.WithNormalizer(registry.FoldCase))
+	r := Open(test, dir, option)
+
+	assert.NoError(test, r.Set("object", "value"))
+	assert.Error(test, r.Sets(registry.Objects{"A": 1, "a": 2}))
+	assert.NoError(test, r.Set("B", "value"))
+	assert.NoError(test, r.Close())
+
+	restored := Open(test, dir, option)
+
+	assert.Equal(test, registry.Objects{"object": "value", "b": "value"}, restored.GetAll())
+}
+
+func TestRegistryReplayCollision(test *testing.T) {
+	dir := TempDir(test)
+	r := Open(test, dir)
+
+	assert.NoError(test, r.Sets(registry.Objects{"A": 1, "a": 2}))
+	assert.NoError(test, r.Close())
+
+	assert.NotPanics(test, func() {
+		_, err := wal.Open(dir, wal.WithRegistryOptions(registry.WithNormalizer(registry.FoldCase)))
+		assert.Error(test, err)
+	})
+}
+
+func TestRegistryOpenError(test *testing.T) {
+	dir := TempDir(test)
+	path := filepath.Join(dir, "file")
+
+	assert.NoError(test, ioutil.WriteFile(path, nil, 0o600))
+
+	_, err := wal.Open(path)
+	assert.Error(test, err)
+
+	assert.NoError(test, ioutil.WriteFile(filepath.Join(dir, wal.SnapshotFile), []byte("invalid"), 0o600))
+
+	_, err = wal.Open(dir)
+	assert.Error(test, err)
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+// EventType defines a type of registry change.
+type EventType int
+
+// Registry change event types.
+const (
+	Added EventType = iota
+	Changed
+	Removed
+)
+
+// Event defines a registry change event.
+type Event struct {
+	Type     EventType
+	Name     string
+	Object   interface{}
+	Previous interface{}
+}
+
+// Listener defines a function called with all changes applied by a single reload.
+type Listener func(events []Event)
+
+// ErrorHandler defines a function called when a background reload fails.
+type ErrorHandler func(err error)
+
+// String returns event type name.
+func (t EventType) String() string {
+	switch t {
+	case Added:
+		return "added"
+	case Changed:
+		return "changed"
+	case Removed:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
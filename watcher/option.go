@@ -0,0 +1,57 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"time"
+
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// DefaultInterval defines a default polling interval.
+const DefaultInterval = time.Second
+
+// Option defines a watcher option used to configure a watcher object.
+type Option func(w *Watcher)
+
+// WithInterval sets a polling interval used to check files for modifications.
+// It must be positive, otherwise New returns an error.
+func WithInterval(interval time.Duration) Option {
+	return func(w *Watcher) {
+		w.interval = interval
+	}
+}
+
+// WithListener sets a listener called with changes applied by every reload,
+// including the initial load.
+func WithListener(listener Listener) Option {
+	return func(w *Watcher) {
+		w.listener = listener
+	}
+}
+
+// WithErrorHandler sets an error handler called when a background reload fails.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(w *Watcher) {
+		w.errorHandler = handler
+	}
+}
+
+// WithRegistryOptions sets options used to create the watched registry.
+func WithRegistryOptions(options ...registry.Option) Option {
+	return func(w *Watcher) {
+		w.options = append(w.options, options...)
+	}
+}
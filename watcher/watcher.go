@@ -0,0 +1,352 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/tymonx/go-error/rterror"
+	"gitlab.com/tymonx/go-patterns/guard"
+	"gitlab.com/tymonx/go-patterns/registry"
+)
+
+// Watcher defines a file-backed registry. It loads registry objects from a
+// local file or from all files in a local directory and polls them for
+// modifications. Changed contents are applied to registry atomically, on
+// errors the previous registry state is kept.
+type Watcher struct {
+	guard        guard.Guard
+	reloading    sync.Mutex
+	registry     *registry.Registry
+	path         string
+	codec        registry.Codec
+	interval     time.Duration
+	listener     Listener
+	errorHandler ErrorHandler
+	options      []registry.Option
+	signature    string
+	checksum     [sha256.Size]byte
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+// file defines a read file.
+type file struct {
+	path string
+	data []byte
+}
+
+// New creates a new watcher object and loads registry objects from a given
+// file or directory encoded with a given codec.
+func New(path string, codec registry.Codec, options ...Option) (*Watcher, error) {
+	w := &Watcher{
+		path:     path,
+		codec:    codec,
+		interval: DefaultInterval,
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	if w.interval <= 0 {
+		return nil, rterror.New("polling interval must be positive", w.interval.String())
+	}
+
+	w.registry = registry.New(w.options...)
+
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Start starts polling files for modifications in background.
+func (w *Watcher) Start() *Watcher {
+	w.guard.Write(func() {
+		if w.stop != nil {
+			return
+		}
+
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+
+		go w.run(w.interval, w.stop, w.done)
+	})
+
+	return w
+}
+
+// Stop stops polling files for modifications and waits for background reload to finish.
+func (w *Watcher) Stop() *Watcher {
+	var done chan struct{}
+
+	w.guard.Write(func() {
+		if w.stop != nil {
+			close(w.stop)
+			done = w.done
+			w.stop, w.done = nil, nil
+		}
+	})
+
+	if done != nil {
+		<-done
+	}
+
+	return w
+}
+
+// Reload checks files for modifications and applies changes to registry.
+// It returns applied changes. On error registry is left unchanged.
+func (w *Watcher) Reload() (events []Event, err error) {
+	w.reloading.Lock()
+	defer w.reloading.Unlock()
+
+	var signature string
+
+	if signature, err = w.stat(); err != nil {
+		return nil, err
+	}
+
+	if signature == w.signature {
+		return nil, nil
+	}
+
+	var files []file
+
+	if files, err = w.read(); err != nil {
+		return nil, err
+	}
+
+	checksum := sum(files)
+
+	if checksum == w.checksum {
+		w.signature = signature
+		return nil, nil
+	}
+
+	next := registry.New(w.options...)
+
+	for _, f := range files {
+		if err = next.Decode(bytes.NewReader(f.data), w.codec); err != nil {
+			return nil, rterror.New("cannot load file", f.path, err)
+		}
+	}
+
+	w.guard.Write(func() {
+		events = apply(w.registry, next)
+	})
+
+	w.signature, w.checksum = signature, checksum
+
+	if (len(events) != 0) && (w.listener != nil) {
+		w.listener(events)
+	}
+
+	return events, nil
+}
+
+// Get returns registered object by given name.
+func (w *Watcher) Get(name string) (object interface{}, err error) {
+	w.guard.Read(func() {
+		object, err = w.registry.Get(name)
+	})
+
+	return object, err
+}
+
+// GetAll returns all registered objects.
+func (w *Watcher) GetAll() (objects registry.Objects) {
+	w.guard.Read(func() {
+		objects = w.registry.GetAll()
+	})
+
+	return objects
+}
+
+// IsExist returns true if object with given name was registered, otherwise it returns false.
+func (w *Watcher) IsExist(name string) (value bool) {
+	w.guard.Read(func() {
+		value = w.registry.IsExist(name)
+	})
+
+	return value
+}
+
+// Read calls a given function with registry locked for reading.
+// Registry must not be modified by a given function.
+func (w *Watcher) Read(function func(r *registry.Registry)) {
+	w.guard.Read(func() {
+		function(w.registry)
+	})
+}
+
+// run polls files for modifications until stopped.
+func (w *Watcher) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := w.Reload(); (err != nil) && (w.errorHandler != nil) {
+				w.errorHandler(err)
+			}
+		}
+	}
+}
+
+// stat returns signature of watched files based on their names, sizes and modification times.
+func (w *Watcher) stat() (string, error) {
+	paths, err := w.paths()
+
+	if err != nil {
+		return "", err
+	}
+
+	var signature strings.Builder
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return "", rterror.New("cannot stat file", path, err)
+		}
+
+		fmt.Fprintf(&signature, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return signature.String(), nil
+}
+
+// read returns contents of watched files.
+func (w *Watcher) read() ([]file, error) {
+	paths, err := w.paths()
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]file, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(filepath.Clean(path))
+
+		if err != nil {
+			return nil, rterror.New("cannot read file", path, err)
+		}
+
+		files = append(files, file{path: path, data: data})
+	}
+
+	return files, nil
+}
+
+// paths returns sorted paths of watched files. For a directory it returns all
+// regular non-hidden files in that directory.
+func (w *Watcher) paths() ([]string, error) {
+	info, err := os.Stat(w.path)
+
+	if err != nil {
+		return nil, rterror.New("cannot stat path", w.path, err)
+	}
+
+	if !info.IsDir() {
+		return []string{w.path}, nil
+	}
+
+	infos, err := ioutil.ReadDir(w.path)
+
+	if err != nil {
+		return nil, rterror.New("cannot read directory", w.path, err)
+	}
+
+	paths := make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		if info.Mode().IsRegular() && !strings.HasPrefix(info.Name(), ".") {
+			paths = append(paths, filepath.Join(w.path, info.Name()))
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// sum returns checksum of given files.
+func sum(files []file) (checksum [sha256.Size]byte) {
+	hash := sha256.New()
+
+	for _, f := range files {
+		fmt.Fprintf(hash, "%s:%d\n", f.path, len(f.data))
+		hash.Write(f.data)
+	}
+
+	copy(checksum[:], hash.Sum(nil))
+
+	return checksum
+}
+
+// apply applies objects from next registry to current registry and returns applied changes.
+func apply(current, next *registry.Registry) []Event {
+	events := []Event{}
+	previous := current.GetAll()
+
+	for _, entry := range next.Entries() {
+		object, ok := previous[entry.Name]
+
+		switch {
+		case !ok:
+			events = append(events, Event{Type: Added, Name: entry.Name, Object: entry.Object})
+		case !reflect.DeepEqual(object, entry.Object):
+			events = append(events, Event{Type: Changed, Name: entry.Name, Object: entry.Object, Previous: object})
+		}
+	}
+
+	for _, entry := range current.Entries() {
+		if !next.IsExist(entry.Name) {
+			events = append(events, Event{Type: Removed, Name: entry.Name, Previous: entry.Object})
+		}
+	}
+
+	for _, event := range events {
+		switch event.Type {
+		case Added:
+			_ = current.Add(event.Name, event.Object)
+		case Changed:
+			current.Set(event.Name, event.Object)
+		case Removed:
+			current.Remove(event.Name)
+		}
+	}
+
+	return events
+}
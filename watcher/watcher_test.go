@@ -0,0 +1,236 @@
+// Copyright 2020 Tymoteusz Blazejczyk
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/tymonx/go-patterns/registry"
+	"gitlab.com/tymonx/go-patterns/watcher"
+)
+
+func TempDir(test *testing.T) string {
+	path, err := ioutil.TempDir("", "watcher")
+
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	test.Cleanup(func() {
+		os.RemoveAll(path)
+	})
+
+	return path
+}
+
+func WriteFile(test *testing.T, path, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0o600); err != nil {
+		test.Fatal(err)
+	}
+
+	// Ensure a different modification time on file systems with coarse timestamps
+	modified := time.Now().Add(time.Duration(len(data)) * time.Second)
+
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		test.Fatal(err)
+	}
+}
+
+func TestWatcherFile(test *testing.T) {
+	path := filepath.Join(TempDir(test), "toggles.json")
+
+	WriteFile(test, path, `{"featureA":{"value":true},"featureB":{"value":false}}`)
+
+	w, err := watcher.New(path, registry.JSON{})
+
+	assert.NoError(test, err)
+	assert.Len(test, w.GetAll(), 2)
+
+	object, err := w.Get("featureA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, true, object)
+}
+
+func TestWatcherReload(test *testing.T) {
+	path := filepath.Join(TempDir(test), "toggles.json")
+
+	WriteFile(test, path, `{"featureA":{"value":true},"featureB":{"value":false}}`)
+
+	w, err := watcher.New(path, registry.JSON{})
+
+	assert.NoError(test, err)
+
+	events, err := w.Reload()
+
+	assert.NoError(test, err)
+	assert.Empty(test, events)
+
+	WriteFile(test, path, `{"featureA":{"value":false},"featureC":{"value":"on"}}`)
+
+	events, err = w.Reload()
+
+	assert.NoError(test, err)
+	assert.Equal(test, []watcher.Event{
+		{Type: watcher.Changed, Name: "featureA", Object: false, Previous: true},
+		{Type: watcher.Added, Name: "featureC", Object: "on"},
+		{Type: watcher.Removed, Name: "featureB", Previous: false},
+	}, events)
+
+	assert.True(test, w.IsExist("featureC"))
+	assert.False(test, w.IsExist("featureB"))
+}
+
+func TestWatcherReloadError(test *testing.T) {
+	path := filepath.Join(TempDir(test), "toggles.json")
+
+	WriteFile(test, path, `{"featureA":{"value":true}}`)
+
+	w, err := watcher.New(path, registry.JSON{})
+
+	assert.NoError(test, err)
+
+	WriteFile(test, path, `{"featureA":`)
+
+	events, err := w.Reload()
+
+	assert.Error(test, err)
+	assert.Empty(test, events)
+	assert.True(test, w.IsExist("featureA"))
+}
+
+func TestWatcherDirectory(test *testing.T) {
+	dir := TempDir(test)
+
+	WriteFile(test, filepath.Join(dir, "a.json"), `{"featureA":{"value":1},"featureB":{"value":1}}`)
+	WriteFile(test, filepath.Join(dir, "b.json"), `{"featureB":{"value":2}}`)
+	WriteFile(test, filepath.Join(dir, ".hidden"), `invalid`)
+
+	w, err := watcher.New(dir, registry.JSON{})
+
+	assert.NoError(test, err)
+	assert.Equal(test, registry.Objects{"featureA": 1.0, "featureB": 2.0}, w.GetAll())
+
+	assert.NoError(test, os.Remove(filepath.Join(dir, "b.json")))
+
+	events, err := w.Reload()
+
+	assert.NoError(test, err)
+	assert.Len(test, events, 1)
+	assert.Equal(test, 1.0, w.GetAll()["featureB"])
+}
+
+func TestWatcherNewError(test *testing.T) {
+	dir := TempDir(test)
+
+	_, err := watcher.New(filepath.Join(dir, "missing.json"), registry.JSON{})
+	assert.Error(test, err)
+
+	WriteFile(test, filepath.Join(dir, "invalid.json"), `invalid`)
+
+	_, err = watcher.New(dir, registry.JSON{})
+	assert.Error(test, err)
+}
+
+func TestWatcherInvalidInterval(test *testing.T) {
+	path := filepath.Join(TempDir(test), "registry.json")
+
+	WriteFile(test, path, `{}`)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		w, err := watcher.New(path, registry.JSON{}, watcher.WithInterval(interval))
+
+		assert.Error(test, err)
+		assert.Nil(test, w)
+	}
+}
+
+func TestWatcherRegistryOptions(test *testing.T) {
+	path := filepath.Join(TempDir(test), "toggles.json")
+
+	WriteFile(test, path, `{"FeatureA":{"value":true}}`)
+
+	w, err := watcher.New(path, registry.JSON{}, watcher.WithRegistryOptions(
+		registry.WithNormalizer(registry.FoldCase),
+	))
+
+	assert.NoError(test, err)
+	assert.True(test, w.IsExist("FEATUREA"))
+
+	w.Read(func(r *registry.Registry) {
+		assert.Equal(test, 1, r.Size())
+	})
+}
+
+func TestWatcherStart(test *testing.T) {
+	path := filepath.Join(TempDir(test), "toggles.json")
+	changes := make(chan []watcher.Event, 1)
+	errs := make(chan error, 1)
+
+	WriteFile(test, path, `{"featureA":{"value":true}}`)
+
+	w, err := watcher.New(path, registry.JSON{},
+		watcher.WithInterval(time.Millisecond),
+		watcher.WithListener(func(events []watcher.Event) {
+			changes <- events
+		}),
+		watcher.WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	assert.NoError(test, err)
+	assert.Equal(test, []watcher.Event{
+		{Type: watcher.Added, Name: "featureA", Object: true},
+	}, <-changes)
+
+	w.Start().Start()
+	defer w.Stop()
+
+	WriteFile(test, path, `{"featureA":{"value":false}}`)
+
+	select {
+	case events := <-changes:
+		assert.Equal(test, []watcher.Event{
+			{Type: watcher.Changed, Name: "featureA", Object: false, Previous: true},
+		}, events)
+	case <-time.After(5 * time.Second):
+		test.Fatal("change was not detected")
+	}
+
+	WriteFile(test, path, `{`)
+
+	select {
+	case err := <-errs:
+		assert.Error(test, err)
+	case <-time.After(5 * time.Second):
+		test.Fatal("error was not reported")
+	}
+
+	w.Stop().Stop()
+
+	object, err := w.Get("featureA")
+
+	assert.NoError(test, err)
+	assert.Equal(test, false, object)
+}